@@ -0,0 +1,128 @@
+package wishlister
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	nanoid "github.com/matoous/go-nanoid/v2"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+// APITokenPrefix is the prefix of all API tokens.
+//
+// It allows to distinguish them from session IDs, and to find them in leaked
+// secrets.
+const APITokenPrefix = "wl_"
+
+func (a *app) CreateAPIToken(
+	ctx context.Context,
+	userID string,
+	name string,
+	readOnly bool,
+) (APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return APIToken{}, "", ErrAPITokenNameEmpty
+	}
+
+	tokenID, _ := nanoid.New()
+	secret, _ := nanoid.New(32)
+	token := APITokenPrefix + secret
+	now := time.Now()
+
+	err := a.queries.InsertAPIToken(ctx, repository.InsertAPITokenParams{
+		ID:        tokenID,
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hashAPIToken(token),
+		ReadOnly:  newBoolInt(readOnly),
+		CreatedAt: now.Unix(),
+	})
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	return APIToken{
+		ID:        tokenID,
+		Name:      name,
+		ReadOnly:  readOnly,
+		CreatedAt: time.Unix(now.Unix(), 0),
+	}, token, nil
+}
+
+func (a *app) GetAPITokens(ctx context.Context, userID string) ([]APIToken, error) {
+	tokensData, err := a.queries.GetUserAPITokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]APIToken, 0, len(tokensData))
+	for _, tokenData := range tokensData {
+		tokens = append(tokens, APIToken{
+			ID:        tokenData.ID,
+			Name:      tokenData.Name,
+			ReadOnly:  tokenData.ReadOnly != 0,
+			CreatedAt: time.Unix(tokenData.CreatedAt, 0),
+		})
+	}
+
+	return tokens, nil
+}
+
+func (a *app) DeleteAPIToken(ctx context.Context, userID string, tokenID string) error {
+	count, err := a.queries.DeleteAPIToken(ctx, repository.DeleteAPITokenParams{
+		ID:     tokenID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+func (a *app) GetSessionByAPIToken(ctx context.Context, token string) (Session, error) {
+	user, err := a.queries.GetUserByAPIToken(ctx, hashAPIToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Session{}, ErrSessionNotFound
+		}
+
+		return Session{}, err
+	}
+
+	return Session{
+		UserID:    user.ID,
+		Username:  user.Username,
+		UserEmail: user.UserEmail,
+		ReadOnly:  user.ReadOnly != 0,
+	}, nil
+}
+
+// hashAPIToken returns the hash of a token, as stored in the database.
+//
+// Tokens are random enough for a fast hash to be safe, and it allows to look them up
+// directly.
+func hashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// newBoolInt converts a boolean to its representation in the database.
+func newBoolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -5,11 +5,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
+	"time"
 
 	nanoid "github.com/matoous/go-nanoid/v2"
+	"golang.org/x/text/language"
 
 	"github.com/erdnaxeli/wishlister/pkg/email"
 	"github.com/erdnaxeli/wishlister/pkg/repository"
+	"github.com/erdnaxeli/wishlister/pkg/repository/postgres"
 )
 
 // CreateWishlistParams represents the parameters to create a new wishlist.
@@ -17,6 +21,14 @@ type CreateWishlistParams struct {
 	Name      string
 	Username  string
 	UserEmail string
+	// EventDate is the date of the event the wishlist is for. It is optional.
+	EventDate time.Time
+	// Lang is the language of the user. If it is undefined, the language already known
+	// for the user is kept.
+	Lang language.Tag
+	// WebhookURLs are the URLs of the webhooks to register on the wishlist. They receive
+	// the list created event. Their secrets can be retrieved with GetWebhooks.
+	WebhookURLs []string
 }
 
 // CreateGroupParams represents the parameters to create a new group.
@@ -32,6 +44,12 @@ type Session struct {
 	UserEmail      string
 	SessionID      string
 	MagicLinkToken string
+	// ReadOnly is true if the session must not be used to modify anything. It is the
+	// case for sessions opened with a read-only API token.
+	ReadOnly bool
+	// ExpiresAt is the time the session ends at the latest. It may end before if it is
+	// not used. It is the zero time for sessions opened with an API token.
+	ExpiresAt time.Time
 }
 
 // WishList represents a wishlist.
@@ -42,6 +60,9 @@ type WishList struct {
 	AdminID  string
 	GroupID  string
 	Username string
+	// EventDate is the date of the event the wishlist is for. It is the zero time if
+	// the wishlist has no event date.
+	EventDate time.Time
 
 	Elements []WishListElement
 }
@@ -53,6 +74,76 @@ type WishListElement struct {
 	URL         string
 }
 
+// ChangeKind is the kind of a change made on the elements of a wishlist.
+type ChangeKind string
+
+const (
+	// ChangeAdded is the kind of a change when an element is added to a wishlist.
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved is the kind of a change when an element is removed from a wishlist.
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// WishListChange represents a change made on the elements of a wishlist.
+type WishListChange struct {
+	ID string
+
+	Kind    ChangeKind
+	Element WishListElement
+	Time    time.Time
+}
+
+// WebhookEvent is the kind of an event sent to webhooks.
+type WebhookEvent string
+
+const (
+	// WebhookListCreated is sent when a wishlist is created.
+	WebhookListCreated WebhookEvent = "list.created"
+	// WebhookElementAdded is sent when an element is added to a wishlist.
+	WebhookElementAdded WebhookEvent = "element.added"
+	// WebhookElementUpdated is sent when an element of a wishlist is modified.
+	WebhookElementUpdated WebhookEvent = "element.updated"
+	// WebhookElementRemoved is sent when an element is removed from a wishlist.
+	WebhookElementRemoved WebhookEvent = "element.removed"
+)
+
+// Webhook represents an URL called on the events of a wishlist.
+type Webhook struct {
+	ID string
+
+	URL string
+	// Secret is the key used to sign the payloads.
+	Secret    string
+	CreatedAt time.Time
+}
+
+// WebhookDelivery represents an attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID string
+
+	WebhookID string
+	URL       string
+	Event     WebhookEvent
+	Attempt   int
+	// StatusCode is the HTTP status code of the response, or 0 if no response was
+	// received.
+	StatusCode int
+	// Error is the reason of the failure, or an empty string if the delivery succeeded.
+	Error string
+	Time  time.Time
+}
+
+// APIToken represents a personal token used to call the API as a user.
+//
+// Only a hash of the token is stored, so its value cannot be retrieved once created.
+type APIToken struct {
+	ID string
+
+	Name      string
+	ReadOnly  bool
+	CreatedAt time.Time
+}
+
 // App is the main interface of this package.
 //
 // It implements all method to manage wishlists.
@@ -65,6 +156,10 @@ type App interface {
 	// Create a new wishlist.
 	//
 	// Return the wishlist id and the admin id.
+	//
+	// If an email address is given and too many emails were sent to it or asked by the
+	// client, the wishlist is not created and an error ErrRateLimited is returned.
+	// If a webhook URL is not a valid HTTP URL, an error ErrWebhookInvalidURL is returned.
 	CreateWishList(ctx context.Context, params CreateWishlistParams) (string, string, error)
 	GetGroup(ctx context.Context, groupID string)
 
@@ -104,46 +199,253 @@ type App interface {
 		elements []WishListElement,
 	) error
 
+	// GetWishListChanges returns the last changes made on the elements of a wishlist,
+	// the most recent first.
+	//
+	// If the wishlist is not found, an error ErrWishListNotFound is returned.
+	GetWishListChanges(ctx context.Context, listID string) ([]WishListChange, error)
+
+	// AddWebhook registers a new webhook on a wishlist.
+	//
+	// This method check that the adminId token is the correct one for this wishlist.
+	//
+	// If the wishlist is not found, an error ErrWishListNotFound is returned.
+	// If the adminId token is incorrect, an error ErrWishListInvalidAdminId is returned.
+	// If the URL is not a valid HTTP URL, an error ErrWebhookInvalidURL is returned.
+	AddWebhook(ctx context.Context, listID string, adminID string, url string) (Webhook, error)
+
+	// GetWebhooks returns the webhooks registered on a wishlist.
+	//
+	// This method check that the adminId token is the correct one for this wishlist.
+	//
+	// If the wishlist is not found, an error ErrWishListNotFound is returned.
+	// If the adminId token is incorrect, an error ErrWishListInvalidAdminId is returned.
+	GetWebhooks(ctx context.Context, listID string, adminID string) ([]Webhook, error)
+
+	// GetWebhookDeliveries returns the last delivery attempts of the webhooks registered
+	// on a wishlist, the most recent first.
+	//
+	// This method check that the adminId token is the correct one for this wishlist.
+	//
+	// If the wishlist is not found, an error ErrWishListNotFound is returned.
+	// If the adminId token is incorrect, an error ErrWishListInvalidAdminId is returned.
+	GetWebhookDeliveries(
+		ctx context.Context,
+		listID string,
+		adminID string,
+	) ([]WebhookDelivery, error)
+
+	// DeleteWebhook deletes a webhook registered on a wishlist.
+	//
+	// This method check that the adminId token is the correct one for this wishlist.
+	//
+	// If the wishlist is not found, an error ErrWishListNotFound is returned.
+	// If the adminId token is incorrect, an error ErrWishListInvalidAdminId is returned.
+	// If the webhook is not found, an error ErrWebhookNotFound is returned.
+	DeleteWebhook(ctx context.Context, listID string, adminID string, webhookID string) error
+
 	// SendMagicLink sends a magic link to the given email address.
 	//
-	// The link can be used to login the user.
-	SendMagicLink(ctx context.Context, email string) error
+	// The link can be used to login the user. The email is written in the given language,
+	// which is saved as the language of the user. If it is undefined, the language
+	// already known for the user is used.
+	//
+	// The link is bound to the login binding of the context, if any (see
+	// WithLoginBinding).
+	//
+	// If too many emails were sent to this address or asked by the client, an error
+	// ErrRateLimited is returned.
+	SendMagicLink(ctx context.Context, email string, lang language.Tag) error
 
 	// GetSession returns the user id associated with the given session id.
 	//
-	// If the session is not found, an error ErrSessionNotFound is returned.
+	// If the session is not found, or if it has expired, an error ErrSessionNotFound is
+	// returned. A session expires after its lifetime, or when it is not used for too
+	// long.
 	GetSession(ctx context.Context, sessionID string) (Session, error)
 
 	// GetSessionByMagicLink returns the user id associated with the given magic link token.
 	//
-	// If the session is not found, an error ErrSessionNotFound is returned.
+	// If the session is not found, or if the magic link has expired, an error
+	// ErrSessionNotFound is returned. If the login binding of the context (see
+	// WithLoginBinding) is not the one the link was asked with, an error
+	// ErrLoginBindingMismatch is returned, unless the login was confirmed (see
+	// WithLoginConfirmed).
 	// Once used, the magic link token is invalidated and cannot be used again.
 	GetSessionByMagicLink(ctx context.Context, token string) (Session, error)
 
 	// DeleteSession deletes the given session.
 	DeleteSession(ctx context.Context, sessionID string)
+
+	// CreateAPIToken creates a new API token for the given user.
+	//
+	// Return the token and its value. The value cannot be retrieved later.
+	//
+	// If the name is empty, an error ErrAPITokenNameEmpty is returned.
+	CreateAPIToken(
+		ctx context.Context,
+		userID string,
+		name string,
+		readOnly bool,
+	) (APIToken, string, error)
+
+	// GetAPITokens returns the API tokens of the given user.
+	GetAPITokens(ctx context.Context, userID string) ([]APIToken, error)
+
+	// DeleteAPIToken revokes an API token of the given user.
+	//
+	// If the token is not found, an error ErrAPITokenNotFound is returned.
+	DeleteAPIToken(ctx context.Context, userID string, tokenID string) error
+
+	// GetSessionByAPIToken returns a session for the user owning the given API token.
+	//
+	// The returned session has no session ID.
+	//
+	// If the token is not found, an error ErrSessionNotFound is returned.
+	GetSessionByAPIToken(ctx context.Context, token string) (Session, error)
+
+	// GetUserCalendarToken returns the calendar token of the given user.
+	//
+	// If the user has no calendar token yet, an empty string is returned.
+	GetUserCalendarToken(ctx context.Context, userID string) (string, error)
+
+	// ResetUserCalendarToken generates a new calendar token for the given user.
+	//
+	// Any previous token is invalidated. Return the new token.
+	ResetUserCalendarToken(ctx context.Context, userID string) (string, error)
+
+	// GetCalendarWishLists returns the wishlists with an event date of the user owning
+	// the given calendar token, ordered by event date.
+	//
+	// Elements are not included in the returned wishlists.
+	//
+	// If the token is not found, an error ErrCalendarNotFound is returned.
+	GetCalendarWishLists(ctx context.Context, token string) ([]WishList, error)
+}
+
+// Engine is a database engine supported by the app.
+type Engine string
+
+const (
+	// EngineSQLite is the SQLite engine.
+	EngineSQLite Engine = "sqlite"
+	// EnginePostgres is the PostgreSQL engine.
+	EnginePostgres Engine = "postgres"
+)
+
+// Config is the configuration of an App.
+type Config struct {
+	// DB is the database, with all the migrations of its engine applied.
+	DB *sql.DB
+	// Engine is the engine of DB. It defaults to EngineSQLite.
+	Engine Engine
+
+	EmailSender email.Sender
+	// DeliverEmails starts a background worker sending the queued emails. It should be
+	// set by the server, and not by short-lived processes.
+	DeliverEmails bool
+	// DeliverWebhooks starts a background worker sending the queued webhook events. Like
+	// DeliverEmails, it should only be set by the server.
+	DeliverWebhooks bool
+	// WebhookAllowPrivateNetworks allows the webhooks to call private addresses, like
+	// the loopback or the local network. It should only be set if all the users are
+	// trusted.
+	WebhookAllowPrivateNetworks bool
+
+	// MagicLinkTTL is how long a magic link can be used after being sent. It defaults
+	// to 15 minutes.
+	MagicLinkTTL time.Duration
+	// SessionLifetime is how long a session lasts after the login, whether it is used
+	// or not. It defaults to 30 days.
+	SessionLifetime time.Duration
+	// SessionIdleTimeout ends the sessions not used for this duration. It defaults to 7
+	// days.
+	SessionIdleTimeout time.Duration
 }
 
 type app struct {
 	db      *sql.DB
-	queries *repository.Queries
-
-	emailSender email.Sender
+	queries repository.Store
+
+	emailSender      email.Sender
+	outboxWakeUp     chan struct{}
+	outboxAttempts   int
+	outboxRetryDelay time.Duration
+
+	rateLimitWindow     time.Duration
+	recipientEmailLimit int64
+	clientEmailLimit    int64
+
+	magicLinkTTL       time.Duration
+	sessionLifetime    time.Duration
+	sessionIdleTimeout time.Duration
+
+	webhookClient       *http.Client
+	webhooksWakeUp      chan struct{}
+	webhookPollInterval time.Duration
+	webhookAttempts     int
+	webhookRetryDelay   time.Duration
 }
 
 // NewWithConfig returns a new App instance.
-func NewWithConfig(db *sql.DB, emailSender email.Sender) (App, error) {
-	err := db.Ping()
+func NewWithConfig(config Config) (App, error) {
+	var queries repository.Store
+	switch config.Engine {
+	case EngineSQLite, "":
+		queries = repository.NewStore(config.DB)
+	case EnginePostgres:
+		queries = postgres.NewStore(config.DB)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, config.Engine)
+	}
+
+	err := config.DB.Ping()
 	if err != nil {
 		return nil, fmt.Errorf("error while pinging database: %w", err)
 	}
 
-	return &app{
-		db:      db,
-		queries: repository.New(db),
+	a := &app{
+		db:      config.DB,
+		queries: queries,
+
+		emailSender:      config.EmailSender,
+		outboxWakeUp:     make(chan struct{}, 1),
+		outboxAttempts:   8,
+		outboxRetryDelay: time.Minute,
+
+		rateLimitWindow:     time.Hour,
+		recipientEmailLimit: 5,
+		clientEmailLimit:    20,
+
+		magicLinkTTL:       durationOrDefault(config.MagicLinkTTL, 15*time.Minute),
+		sessionLifetime:    durationOrDefault(config.SessionLifetime, 30*24*time.Hour),
+		sessionIdleTimeout: durationOrDefault(config.SessionIdleTimeout, 7*24*time.Hour),
+
+		webhookClient:       newWebhookClient(config.WebhookAllowPrivateNetworks),
+		webhooksWakeUp:      make(chan struct{}, 1),
+		webhookPollInterval: 5 * time.Second,
+		webhookAttempts:     4,
+		webhookRetryDelay:   5 * time.Second,
+	}
+
+	if config.DeliverEmails {
+		go a.runOutbox(context.Background())
+	}
+
+	if config.DeliverWebhooks {
+		go a.runWebhooks(context.Background())
+	}
+
+	return a, nil
+}
+
+// durationOrDefault returns the given duration, or the default one if it is not set.
+func durationOrDefault(duration time.Duration, defaultDuration time.Duration) time.Duration {
+	if duration <= 0 {
+		return defaultDuration
+	}
 
-		emailSender: emailSender,
-	}, nil
+	return duration
 }
 
 func (a *app) CreateGroup(ctx context.Context, params CreateGroupParams) (string, error) {
@@ -171,7 +473,7 @@ func (a *app) UpdateListElements(
 	adminID string,
 	elements []WishListElement,
 ) (err error) {
-	_, err = a.checkListEditAccess(ctx, listID, adminID)
+	list, err := a.checkListEditAccess(ctx, listID, adminID)
 	if err != nil {
 		return err
 	}
@@ -187,6 +489,11 @@ func (a *app) UpdateListElements(
 	}()
 
 	qtx := a.queries.WithTx(tx)
+	previousElements, err := getElements(ctx, qtx, listID)
+	if err != nil {
+		return err
+	}
+
 	err = qtx.DeleteWishListElements(ctx, listID)
 	if err != nil {
 		return err
@@ -209,11 +516,23 @@ func (a *app) UpdateListElements(
 		}
 	}
 
+	err = recordChanges(ctx, qtx, listID, previousElements, elements)
+	if err != nil {
+		return err
+	}
+
+	err = queueElementsWebhooks(ctx, qtx, list, previousElements, elements)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
 	}
 
+	a.wakeUpWebhooks()
+
 	return nil
 }
 
@@ -0,0 +1,93 @@
+package wishlister
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	nanoid "github.com/matoous/go-nanoid/v2"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+// eventDateLayout is the layout used to store event dates in the database.
+const eventDateLayout = time.DateOnly
+
+func (a *app) GetUserCalendarToken(ctx context.Context, userID string) (string, error) {
+	token, err := a.queries.GetUserCalendarToken(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return token.String, nil
+}
+
+func (a *app) ResetUserCalendarToken(ctx context.Context, userID string) (string, error) {
+	token, _ := nanoid.New()
+
+	err := a.queries.SetUserCalendarToken(ctx, repository.SetUserCalendarTokenParams{
+		CalendarToken: NewNullString(token),
+		ID:            userID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (a *app) GetCalendarWishLists(ctx context.Context, token string) ([]WishList, error) {
+	user, err := a.queries.GetUserByCalendarToken(ctx, NewNullString(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCalendarNotFound
+		}
+
+		return nil, err
+	}
+
+	listsData, err := a.queries.GetUserEventWishLists(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	wishLists := make([]WishList, 0, len(listsData))
+	for _, listData := range listsData {
+		wishLists = append(wishLists, WishList{
+			ID:        listData.ID,
+			Name:      listData.Name,
+			Username:  listData.Username,
+			EventDate: parseEventDate(listData.EventDate),
+		})
+	}
+
+	return wishLists, nil
+}
+
+// newEventDate converts an event date to a sql.NullString value.
+//
+// If the date is the zero time, the NullString is invalid.
+func newEventDate(date time.Time) sql.NullString {
+	if date.IsZero() {
+		return sql.NullString{}
+	}
+
+	return NewNullString(date.Format(eventDateLayout))
+}
+
+// parseEventDate converts an event date stored in the database to a time.Time value.
+//
+// If the date is null or invalid, the zero time is returned.
+func parseEventDate(date sql.NullString) time.Time {
+	if !date.Valid {
+		return time.Time{}
+	}
+
+	eventDate, err := time.Parse(eventDateLayout, date.String)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return eventDate
+}
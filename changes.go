@@ -0,0 +1,150 @@
+package wishlister
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	nanoid "github.com/matoous/go-nanoid/v2"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+// maxChanges is the maximum number of changes returned by GetWishListChanges.
+const maxChanges = 50
+
+func (a *app) GetWishListChanges(ctx context.Context, listID string) ([]WishListChange, error) {
+	// ensure the list exists
+	_, err := a.getWishList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	changesData, err := a.queries.GetWishListChanges(ctx, repository.GetWishListChangesParams{
+		WishlistID: listID,
+		Limit:      maxChanges,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]WishListChange, 0, len(changesData))
+	for _, changeData := range changesData {
+		changes = append(changes, WishListChange{
+			ID:   changeData.ID,
+			Kind: ChangeKind(changeData.Kind),
+			Element: WishListElement{
+				Name:        changeData.ElementName,
+				Description: changeData.ElementDescription.String,
+				URL:         changeData.ElementUrl.String,
+			},
+			Time: time.Unix(changeData.CreatedAt, 0),
+		})
+	}
+
+	return changes, nil
+}
+
+// recordChanges saves the changes between the previous and the new elements of a
+// wishlist.
+func recordChanges(
+	ctx context.Context,
+	queries repository.Querier,
+	listID string,
+	previousElements []WishListElement,
+	newElements []WishListElement,
+) error {
+	now := time.Now().Unix()
+	added, removed := diffElements(previousElements, newElements)
+
+	for _, changes := range []struct {
+		kind     ChangeKind
+		elements []WishListElement
+	}{
+		{kind: ChangeRemoved, elements: removed},
+		{kind: ChangeAdded, elements: added},
+	} {
+		for _, element := range changes.elements {
+			changeID, _ := nanoid.New()
+			err := queries.InsertWishListChange(ctx, repository.InsertWishListChangeParams{
+				ID:                 changeID,
+				WishlistID:         listID,
+				Kind:               string(changes.kind),
+				ElementName:        element.Name,
+				ElementDescription: NewNullString(element.Description),
+				ElementUrl:         NewNullString(element.URL),
+				CreatedAt:          now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// diffElements returns the elements added and removed between two versions of a
+// wishlist.
+//
+// An element modified is seen as removed then added.
+func diffElements(
+	previousElements []WishListElement,
+	newElements []WishListElement,
+) ([]WishListElement, []WishListElement) {
+	// A list can contain the same element multiple times, so we count them.
+	counts := make(map[WishListElement]int)
+	for _, element := range previousElements {
+		counts[element]++
+	}
+
+	var added []WishListElement
+	for _, element := range newElements {
+		if counts[element] > 0 {
+			counts[element]--
+		} else {
+			added = append(added, element)
+		}
+	}
+
+	var removed []WishListElement
+	for _, element := range previousElements {
+		if counts[element] > 0 {
+			counts[element]--
+			removed = append(removed, element)
+		}
+	}
+
+	return added, removed
+}
+
+// diffElementsByName returns the elements added, updated and removed between two
+// versions of a wishlist.
+//
+// An element removed and another one added with the same name are considered as the
+// same element updated. Updated elements are returned as pairs of the previous and the
+// new version.
+func diffElementsByName(
+	previousElements []WishListElement,
+	newElements []WishListElement,
+) ([]WishListElement, [][2]WishListElement, []WishListElement) {
+	added, removed := diffElements(previousElements, newElements)
+
+	var reallyAdded []WishListElement
+	var updated [][2]WishListElement
+
+	for _, element := range added {
+		idx := slices.IndexFunc(removed, func(e WishListElement) bool {
+			return e.Name == element.Name
+		})
+		if idx == -1 {
+			reallyAdded = append(reallyAdded, element)
+			continue
+		}
+
+		updated = append(updated, [2]WishListElement{removed[idx], element})
+		removed = slices.Delete(removed, idx, idx+1)
+	}
+
+	return reallyAdded, updated, removed
+}
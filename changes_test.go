@@ -0,0 +1,75 @@
+package wishlister_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+)
+
+// TestWishListChangesOrder checks that the changes written by the same update, which
+// share their time, are always returned in the same order.
+func TestWishListChangesOrder(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		app, err := wishlister.NewWithConfig(wishlister.Config{
+			DB:          db,
+			Engine:      engine,
+			EmailSender: email.NoMailer{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		listID, adminID, err := app.CreateWishList(ctx, wishlister.CreateWishlistParams{
+			Name:     "Birthday",
+			Username: "Alice",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, names := range [][]string{{"A", "B", "C"}, {"D", "E", "F"}} {
+			var elements []wishlister.WishListElement
+			for _, name := range names {
+				elements = append(elements, wishlister.WishListElement{Name: name})
+			}
+
+			err = app.UpdateListElements(ctx, listID, adminID, elements)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// The most recent changes come first.
+		expected := []string{
+			"added F", "added E", "added D",
+			"removed C", "removed B", "removed A",
+			"added C", "added B", "added A",
+		}
+		for range 5 {
+			changes, err := app.GetWishListChanges(ctx, listID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, change := range changes {
+				got = append(got, string(change.Kind)+" "+change.Element.Name)
+			}
+
+			if len(got) != len(expected) {
+				t.Fatalf("got changes %v, expected %v", got, expected)
+			}
+
+			for i := range got {
+				if got[i] != expected[i] {
+					t.Fatalf("got changes %v, expected %v", got, expected)
+				}
+			}
+		}
+	})
+}
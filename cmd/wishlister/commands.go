@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+// ErrTokenRequired is the error when a command needs an API token but none was given.
+var ErrTokenRequired = errors.New("an API token is required, use --token or WISHLISTER_TOKEN")
+
+// ErrListNotFound is the error when a list given by its name cannot be found.
+var ErrListNotFound = errors.New("list not found")
+
+// ErrListNotEditable is the error when a list must be edited but its admin ID is
+// unknown.
+var ErrListNotEditable = errors.New(
+	"cannot edit this list, give its admin link or use a token of its owner",
+)
+
+// ErrElementNotFound is the error when an element to remove cannot be found.
+var ErrElementNotFound = errors.New("element not found")
+
+// listRef identifies a list, and optionally its admin ID.
+type listRef struct {
+	id      string
+	adminID string
+}
+
+func listLists(ctx context.Context, c cli, _ []string) error {
+	lists, err := c.getUserWishLists(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.printLists(lists)
+}
+
+func showList(ctx context.Context, c cli, args []string) error {
+	ref, err := c.resolveList(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	list, err := c.getList(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	return c.printList(list)
+}
+
+func addElement(ctx context.Context, c cli, args []string) error {
+	list, err := c.getEditableList(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	list.Elements = append(list.Elements, wishlister.WishListElement{
+		Name:        args[1],
+		Description: c.description,
+		URL:         c.elementURL,
+	})
+
+	err = c.client.UpdateListElements(ctx, list.ID, list.AdminID, list.Elements)
+	if err != nil {
+		return err
+	}
+
+	return c.printList(list)
+}
+
+func removeElement(ctx context.Context, c cli, args []string) error {
+	list, err := c.getEditableList(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(list.Elements, func(e wishlister.WishListElement) bool {
+		return e.Name == args[1]
+	})
+	if idx == -1 {
+		return fmt.Errorf("%w: %s", ErrElementNotFound, args[1])
+	}
+
+	list.Elements = slices.Delete(list.Elements, idx, idx+1)
+
+	err = c.client.UpdateListElements(ctx, list.ID, list.AdminID, list.Elements)
+	if err != nil {
+		return err
+	}
+
+	return c.printList(list)
+}
+
+func exportLists(ctx context.Context, c cli, _ []string) error {
+	userLists, err := c.getUserWishLists(ctx)
+	if err != nil {
+		return err
+	}
+
+	lists := make([]wishlister.WishList, 0, len(userLists))
+	for _, userList := range userLists {
+		list, err := c.getList(ctx, listRef{id: userList.ID, adminID: userList.AdminID})
+		if err != nil {
+			return err
+		}
+
+		lists = append(lists, list)
+	}
+
+	return c.printExport(lists)
+}
+
+// getUserWishLists returns the lists of the owner of the token.
+func (c cli) getUserWishLists(ctx context.Context) ([]wishlister.WishList, error) {
+	if c.token == "" {
+		return nil, ErrTokenRequired
+	}
+
+	// The session endpoint accepts both session IDs and API tokens.
+	session, err := c.client.GetSession(ctx, c.token)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.GetUserWishLists(ctx, session.UserID)
+}
+
+// resolveList returns the list corresponding to the argument given by the user.
+//
+// The argument can be a link to the list (with or without its admin ID), or the ID or
+// name of one of the lists of the owner of the token.
+func (c cli) resolveList(ctx context.Context, arg string) (listRef, error) {
+	if ref, ok := parseListLink(arg); ok {
+		return ref, nil
+	}
+
+	if c.token == "" {
+		return listRef{id: arg}, nil
+	}
+
+	lists, err := c.getUserWishLists(ctx)
+	if err != nil {
+		return listRef{}, err
+	}
+
+	for _, list := range lists {
+		if list.ID == arg || strings.EqualFold(list.Name, arg) {
+			return listRef{id: list.ID, adminID: list.AdminID}, nil
+		}
+	}
+
+	// It may be the ID of a list of someone else.
+	return listRef{id: arg}, nil
+}
+
+func (c cli) getList(ctx context.Context, ref listRef) (wishlister.WishList, error) {
+	var (
+		list wishlister.WishList
+		err  error
+	)
+
+	if ref.adminID != "" {
+		list, err = c.client.GetEditableWishList(ctx, ref.id, ref.adminID)
+	} else {
+		list, err = c.client.GetWishList(ctx, ref.id)
+	}
+
+	if errors.Is(err, wishlister.ErrWishListNotFound) {
+		return wishlister.WishList{}, fmt.Errorf("%w: %s", ErrListNotFound, ref.id)
+	}
+
+	return list, err
+}
+
+func (c cli) getEditableList(ctx context.Context, arg string) (wishlister.WishList, error) {
+	ref, err := c.resolveList(ctx, arg)
+	if err != nil {
+		return wishlister.WishList{}, err
+	}
+
+	if ref.adminID == "" {
+		return wishlister.WishList{}, ErrListNotEditable
+	}
+
+	return c.getList(ctx, ref)
+}
+
+// parseListLink parses a link to a list, like https://example.org/l/{listID}/{adminID}.
+func parseListLink(link string) (listRef, bool) {
+	listID, adminID, ok := urls.ParseWishList(link)
+	return listRef{id: listID, adminID: adminID}, ok
+}
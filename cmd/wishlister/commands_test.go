@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseListLink(t *testing.T) {
+	for _, test := range []struct {
+		link     string
+		expected listRef
+		ok       bool
+	}{
+		{
+			link:     "https://example.org/l/listID",
+			expected: listRef{id: "listID"},
+			ok:       true,
+		},
+		{
+			link:     "https://example.org/l/listID/adminID",
+			expected: listRef{id: "listID", adminID: "adminID"},
+			ok:       true,
+		},
+		{
+			link:     "https://example.org/l/listID/adminID/",
+			expected: listRef{id: "listID", adminID: "adminID"},
+			ok:       true,
+		},
+		{
+			link:     "https://example.org/l/listID/adminID/edit",
+			expected: listRef{id: "listID", adminID: "adminID"},
+			ok:       true,
+		},
+		{
+			// The app may be served under a path.
+			link:     "https://example.org/wishlister/l/listID/adminID",
+			expected: listRef{id: "listID", adminID: "adminID"},
+			ok:       true,
+		},
+		{link: "https://example.org/l/listID/print.pdf"},
+		{link: "https://example.org/l/listID/feed.atom"},
+		{link: "https://example.org/l/listID/adminID/webhooks"},
+		{link: "https://example.org/l/listID/adminID/edit/more"},
+		{link: "https://example.org/l"},
+		{link: "https://example.org/lists"},
+		{link: "https://example.org/"},
+		// Not a link, like a list name or ID.
+		{link: "listID"},
+		{link: "l/listID/adminID"},
+	} {
+		ref, ok := parseListLink(test.link)
+		if ok != test.ok || (ok && ref != test.expected) {
+			t.Errorf(
+				"%s: got %+v, %t, expected %+v, %t",
+				test.link,
+				ref,
+				ok,
+				test.expected,
+				test.ok,
+			)
+		}
+	}
+}
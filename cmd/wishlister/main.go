@@ -0,0 +1,179 @@
+// Implements a command line client to manage wishlists on a wishlister server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/caarlos0/env/v11"
+
+	"github.com/erdnaxeli/wishlister/pkg/client"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+const usage = `Usage: wishlister <command> [options] [arguments]
+
+Commands:
+  lists                        list your wishlists
+  show <list>                  show a wishlist and its elements
+  add <list> <name>            add an element to a wishlist
+  remove <list> <name>         remove an element from a wishlist
+  export                       export all your wishlists with their elements
+
+A list is given by its ID, its name (only with a token), or its admin link.
+
+Options:
+  --server URL                 URL of the server, required (env WISHLISTER_URL)
+  --token TOKEN                API token (env WISHLISTER_TOKEN)
+  --output table|json          output format (default table)
+
+Options of add:
+  --description TEXT           description of the element
+  --url URL                    link of the element
+`
+
+// ErrUsage is the error when the command line is invalid.
+var ErrUsage = errors.New("invalid usage")
+
+// ErrServerRequired is the error when no server URL was given.
+var ErrServerRequired = errors.New(
+	"the URL of the server is required, use --server or WISHLISTER_URL",
+)
+
+type config struct {
+	URL   string `env:"WISHLISTER_URL"`
+	Token string `env:"WISHLISTER_TOKEN"`
+}
+
+// options holds the options common to all commands.
+type options struct {
+	url    string
+	token  string
+	output string
+}
+
+// command is a CLI command.
+type command struct {
+	run   func(ctx context.Context, cli cli, args []string) error
+	nArgs int
+	// flags registers the command specific flags.
+	flags func(fs *flag.FlagSet)
+}
+
+// cli holds everything needed to run a command.
+type cli struct {
+	client *client.Client
+	urls   urls.Builder
+	// token is the API token, used to find the lists of the user.
+	token  string
+	out    io.Writer
+	format outputFormat
+
+	// add command options
+	description string
+	elementURL  string
+}
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdout)
+	if err != nil {
+		if errors.Is(err, ErrUsage) {
+			_, _ = fmt.Fprint(os.Stderr, usage)
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	var cfg config
+	err := env.Parse(&cfg)
+	if err != nil {
+		return fmt.Errorf("error while reading configuration: %w", err)
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing command", ErrUsage)
+	}
+
+	var c cli
+	commands := map[string]command{
+		"lists":  {run: listLists},
+		"show":   {run: showList, nArgs: 1},
+		"add":    {run: addElement, nArgs: 2, flags: c.addFlags},
+		"remove": {run: removeElement, nArgs: 2},
+		"export": {run: exportLists},
+	}
+
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("%w: unknown command %s", ErrUsage, name)
+	}
+
+	opts := options{url: cfg.URL, token: cfg.Token}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.url, "server", opts.url, "")
+	fs.StringVar(&opts.token, "token", opts.token, "")
+	fs.StringVar(&opts.output, "output", string(outputTable), "")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+
+	positional, err := parseFlags(fs, args[1:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	if len(positional) != cmd.nArgs {
+		return fmt.Errorf("%w: wrong number of arguments for %s", ErrUsage, name)
+	}
+
+	c.format = outputFormat(opts.output)
+	if c.format != outputTable && c.format != outputJSON {
+		return fmt.Errorf("%w: unknown output format %s", ErrUsage, opts.output)
+	}
+
+	if opts.url == "" {
+		return ErrServerRequired
+	}
+
+	c.client = client.New(client.Config{BaseURL: opts.url, Token: opts.token})
+	c.urls = urls.New(opts.url)
+	c.token = opts.token
+	c.out = out
+
+	return cmd.run(ctx, c, positional)
+}
+
+func (c *cli) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.description, "description", "", "")
+	fs.StringVar(&c.elementURL, "url", "", "")
+}
+
+// parseFlags parses the flags, which can be placed anywhere in the arguments.
+//
+// It returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		err := fs.Parse(args)
+		if err != nil {
+			return nil, err
+		}
+
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
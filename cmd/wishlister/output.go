@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table"
+	outputJSON  outputFormat = "json"
+)
+
+type jsonList struct {
+	ID        string        `json:"id"`
+	AdminID   string        `json:"admin_id,omitempty"`
+	Name      string        `json:"name"`
+	Username  string        `json:"username,omitempty"`
+	EventDate string        `json:"event_date,omitempty"`
+	Link      string        `json:"link"`
+	Elements  []jsonElement `json:"elements,omitempty"`
+}
+
+type jsonElement struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+func (c cli) printLists(lists []wishlister.WishList) error {
+	if c.format == outputJSON {
+		return c.printJSON(c.newJSONLists(lists))
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tDATE\tLINK")
+	for _, list := range lists {
+		_, _ = fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\n",
+			list.ID,
+			list.Name,
+			formatDate(list.EventDate),
+			c.listLink(list),
+		)
+	}
+
+	return w.Flush()
+}
+
+func (c cli) printList(list wishlister.WishList) error {
+	if c.format == outputJSON {
+		return c.printJSON(c.newJSONList(list))
+	}
+
+	return c.printListTable(list)
+}
+
+func (c cli) printExport(lists []wishlister.WishList) error {
+	if c.format == outputJSON {
+		return c.printJSON(c.newJSONLists(lists))
+	}
+
+	for idx, list := range lists {
+		if idx > 0 {
+			_, _ = fmt.Fprintln(c.out)
+		}
+
+		err := c.printListTable(list)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c cli) printListTable(list wishlister.WishList) error {
+	_, _ = fmt.Fprintf(c.out, "%s (%s)\n", list.Name, c.listLink(list))
+	if !list.EventDate.IsZero() {
+		_, _ = fmt.Fprintf(c.out, "Date: %s\n", formatDate(list.EventDate))
+	}
+
+	if len(list.Elements) == 0 {
+		_, _ = fmt.Fprintln(c.out, "This list is empty.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tDESCRIPTION\tURL")
+	for _, element := range list.Elements {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", element.Name, element.Description, element.URL)
+	}
+
+	return w.Flush()
+}
+
+func (c cli) printJSON(data any) error {
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(data)
+}
+
+// listLink returns the link to the list, with its admin ID if known.
+func (c cli) listLink(list wishlister.WishList) string {
+	if list.AdminID != "" {
+		return c.urls.EditableWishList(list.ID, list.AdminID)
+	}
+
+	return c.urls.WishList(list.ID)
+}
+
+func (c cli) newJSONLists(lists []wishlister.WishList) []jsonList {
+	resp := make([]jsonList, 0, len(lists))
+	for _, list := range lists {
+		resp = append(resp, c.newJSONList(list))
+	}
+
+	return resp
+}
+
+func (c cli) newJSONList(list wishlister.WishList) jsonList {
+	resp := jsonList{
+		ID:        list.ID,
+		AdminID:   list.AdminID,
+		Name:      list.Name,
+		Username:  list.Username,
+		EventDate: formatDate(list.EventDate),
+		Link:      c.listLink(list),
+	}
+
+	for _, element := range list.Elements {
+		resp.Elements = append(resp.Elements, jsonElement{
+			Name:        element.Name,
+			Description: element.Description,
+			URL:         element.URL,
+		})
+	}
+
+	return resp
+}
+
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+
+	return date.Format(time.DateOnly)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+func TestOutput(t *testing.T) {
+	lists := []wishlister.WishList{
+		{
+			ID:        "list1",
+			AdminID:   "admin1",
+			Name:      "Birthday",
+			Username:  "Alice",
+			EventDate: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC),
+			Elements: []wishlister.WishListElement{
+				{Name: "Book", Description: "A novel", URL: "https://example.org/book"},
+				{Name: "Pen"},
+			},
+		},
+		{ID: "list2", Name: "Other"},
+	}
+
+	for _, test := range []struct {
+		name     string
+		format   outputFormat
+		print    func(c cli) error
+		expected string
+	}{
+		{
+			name:   "lists table",
+			format: outputTable,
+			print:  func(c cli) error { return c.printLists(lists) },
+			expected: "ID     NAME      DATE        LINK\n" +
+				"list1  Birthday  2026-12-25  https://example.org/l/list1/admin1\n" +
+				"list2  Other                 https://example.org/l/list2\n",
+		},
+		{
+			name:   "list table",
+			format: outputTable,
+			print:  func(c cli) error { return c.printList(lists[0]) },
+			expected: "Birthday (https://example.org/l/list1/admin1)\n" +
+				"Date: 2026-12-25\n" +
+				"NAME  DESCRIPTION  URL\n" +
+				"Book  A novel      https://example.org/book\n" +
+				"Pen                \n",
+		},
+		{
+			name:   "empty list table",
+			format: outputTable,
+			print:  func(c cli) error { return c.printList(lists[1]) },
+			expected: "Other (https://example.org/l/list2)\n" +
+				"This list is empty.\n",
+		},
+		{
+			name:   "export table",
+			format: outputTable,
+			print:  func(c cli) error { return c.printExport(lists) },
+			expected: "Birthday (https://example.org/l/list1/admin1)\n" +
+				"Date: 2026-12-25\n" +
+				"NAME  DESCRIPTION  URL\n" +
+				"Book  A novel      https://example.org/book\n" +
+				"Pen                \n" +
+				"\n" +
+				"Other (https://example.org/l/list2)\n" +
+				"This list is empty.\n",
+		},
+		{
+			name:   "lists json",
+			format: outputJSON,
+			print:  func(c cli) error { return c.printLists(lists) },
+			expected: `[
+  {
+    "id": "list1",
+    "admin_id": "admin1",
+    "name": "Birthday",
+    "username": "Alice",
+    "event_date": "2026-12-25",
+    "link": "https://example.org/l/list1/admin1",
+    "elements": [
+      {
+        "name": "Book",
+        "description": "A novel",
+        "url": "https://example.org/book"
+      },
+      {
+        "name": "Pen"
+      }
+    ]
+  },
+  {
+    "id": "list2",
+    "name": "Other",
+    "link": "https://example.org/l/list2"
+  }
+]
+`,
+		},
+	} {
+		var out bytes.Buffer
+		c := cli{urls: urls.New("https://example.org/"), out: &out, format: test.format}
+
+		err := test.print(c)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		if out.String() != test.expected {
+			t.Errorf("%s: got\n%s\nexpected\n%s", test.name, out.String(), test.expected)
+		}
+	}
+}
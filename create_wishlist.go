@@ -2,9 +2,9 @@ package wishlister
 
 import (
 	"context"
-	"log"
 
 	nanoid "github.com/matoous/go-nanoid/v2"
+	"golang.org/x/text/language"
 
 	"github.com/erdnaxeli/wishlister/pkg/repository"
 )
@@ -21,44 +21,54 @@ func (a *app) CreateWishList(
 		return "", "", ErrWishListUsernameEmpty
 	}
 
+	for _, webhookURL := range params.WebhookURLs {
+		err = checkWebhookURL(webhookURL)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if params.UserEmail != "" {
+		err = a.checkEmailRateLimits(ctx, params.UserEmail)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
 	listID, _ = nanoid.New()
 	adminID, _ = nanoid.New()
 
-	userID, err := a.GetOrCreateUser(
+	userID, lang, err := a.GetOrCreateUser(
 		ctx,
 		params.Username,
 		params.UserEmail,
+		params.Lang,
 	)
 	if err != nil {
 		return "", "", err
 	}
 
-	err = a.createWishList(ctx, listID, adminID, params.Name, userID)
+	err = a.createWishList(ctx, listID, adminID, params, userID, lang)
 	if err != nil {
 		return "", "", err
 	}
 
-	err = a.emailSender.SendNewWishListEmail(
-		ctx,
-		params.UserEmail,
-		params.Username,
-		listID,
-		adminID,
-	)
-	if err != nil {
-		log.Print(err)
-	}
+	a.wakeUpOutbox()
+	a.wakeUpWebhooks()
 
 	return listID, adminID, nil
 }
 
+// createWishList saves a new wishlist with its webhooks, and queues the email sent to
+// its user if they gave an email address and the list created event of the webhooks.
 func (a *app) createWishList(
 	ctx context.Context,
 	listID string,
 	adminID string,
-	name string,
+	params CreateWishlistParams,
 	userID string,
-) error {
+	lang language.Tag,
+) (err error) {
 	tx, err := a.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -71,15 +81,49 @@ func (a *app) createWishList(
 
 	qtx := a.queries.WithTx(tx)
 	err = qtx.CreateWishList(ctx, repository.CreateWishListParams{
-		ID:      listID,
-		AdminID: adminID,
-		Name:    name,
-		UserID:  userID,
+		ID:        listID,
+		AdminID:   adminID,
+		Name:      params.Name,
+		UserID:    userID,
+		EventDate: newEventDate(params.EventDate),
 	})
 	if err != nil {
 		return err
 	}
 
+	webhooks := make([]Webhook, 0, len(params.WebhookURLs))
+	for _, webhookURL := range params.WebhookURLs {
+		webhook, err := insertWebhook(ctx, qtx, listID, webhookURL)
+		if err != nil {
+			return err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	err = queueListCreatedWebhooks(ctx, qtx, WishList{ID: listID, Name: params.Name}, webhooks)
+	if err != nil {
+		return err
+	}
+
+	if params.UserEmail != "" {
+		err = queueEmail(
+			ctx,
+			qtx,
+			outboxWishListCreated,
+			params.UserEmail,
+			lang,
+			outboxWishListCreatedPayload{
+				Username: params.Username,
+				ListID:   listID,
+				AdminID:  adminID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
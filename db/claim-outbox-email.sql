@@ -0,0 +1,7 @@
+-- name: ClaimOutboxEmail :execrows
+update email_outbox
+set next_attempt_at = sqlc.arg(claimed_until)
+where id = sqlc.arg(id)
+    and status = 'pending'
+    -- the email was not claimed by another worker in the meantime
+    and next_attempt_at = sqlc.arg(next_attempt_at);
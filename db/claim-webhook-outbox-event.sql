@@ -0,0 +1,6 @@
+-- name: ClaimWebhookOutboxEvent :execrows
+update webhook_outbox
+set next_attempt_at = sqlc.arg(claimed_until)
+where id = sqlc.arg(id)
+    -- the event was not claimed by another worker in the meantime
+    and next_attempt_at = sqlc.arg(next_attempt_at);
@@ -1,3 +1,5 @@
 -- name: CreateUserSession :exec
-insert into user_sessions (id, user_id, magic_link_token)
-values (?, ?, ?);
+insert into user_sessions (
+    id, user_id, magic_link_token, magic_link_binding, created_at, last_used_at
+)
+values (?, ?, ?, ?, ?, ?);
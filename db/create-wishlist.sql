@@ -1,7 +1,7 @@
 -- name: CreateWishList :exec
 insert into wishlists (
-    id, admin_id, name, group_id, user_id
+    id, admin_id, name, group_id, user_id, event_date
 )
 values (
-    ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?
 );
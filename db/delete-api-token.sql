@@ -0,0 +1,3 @@
+-- name: DeleteAPIToken :execrows
+delete from api_tokens
+where id = ? and user_id = ?;
@@ -0,0 +1,3 @@
+-- name: DeleteExpiredRateLimits :exec
+delete from rate_limits
+where window_start < ?;
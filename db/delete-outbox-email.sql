@@ -0,0 +1,3 @@
+-- name: DeleteOutboxEmail :exec
+delete from email_outbox
+where id = ?;
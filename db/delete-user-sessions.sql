@@ -0,0 +1,3 @@
+-- name: DeleteUserSessions :execrows
+delete from user_sessions
+where user_id = ?;
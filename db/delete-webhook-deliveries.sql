@@ -0,0 +1,3 @@
+-- name: DeleteWebhookDeliveries :exec
+delete from webhook_deliveries
+where webhook_id = ?;
@@ -0,0 +1,3 @@
+-- name: DeleteWebhookOutboxEvent :exec
+delete from webhook_outbox
+where id = ?;
@@ -0,0 +1,3 @@
+-- name: DeleteWebhook :execrows
+delete from webhooks
+where id = ? and wishlist_id = ?;
@@ -0,0 +1,3 @@
+-- name: DeleteWishList :execrows
+delete from wishlists
+where id = ?;
@@ -0,0 +1,7 @@
+-- name: GetDueOutboxEmails :many
+select id, kind, recipient, lang, payload, attempts, next_attempt_at
+from email_outbox
+where status = 'pending'
+    and next_attempt_at <= ?
+order by next_attempt_at
+limit ?;
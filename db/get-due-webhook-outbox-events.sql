@@ -0,0 +1,15 @@
+-- name: GetDueWebhookOutboxEvents :many
+select
+    webhook_outbox.id,
+    webhook_id,
+    webhooks.url,
+    webhooks.secret,
+    event,
+    payload,
+    attempts,
+    next_attempt_at
+from webhook_outbox
+join webhooks on webhooks.id = webhook_outbox.webhook_id
+where next_attempt_at <= ?
+order by next_attempt_at, webhook_outbox.created_at
+limit ?;
@@ -0,0 +1,5 @@
+-- name: GetFailedOutboxEmails :many
+select id, kind, recipient, attempts, last_error, created_at
+from email_outbox
+where status = 'failed'
+order by created_at;
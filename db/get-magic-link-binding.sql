@@ -0,0 +1,8 @@
+-- name: GetMagicLinkBinding :one
+select magic_link_binding
+from user_sessions
+where magic_link_token = sqlc.arg(magic_link_token)
+    -- safety check to ensure the given token is not null
+    and magic_link_token is not null
+    -- the magic link has not expired
+    and created_at >= sqlc.arg(created_after);
@@ -1,5 +1,8 @@
 -- name: GetOrCreateUser :one
-insert into users (id, name, email)
-values (?, ?, ?)
-on conflict (email) do update set name = excluded.name
-returning id, name, email;
+insert into users (id, name, email, lang)
+values (?, ?, ?, ?)
+on conflict (email) do update set
+    name = excluded.name,
+    -- an empty language keeps the known one
+    lang = coalesce(nullif(excluded.lang, ''), users.lang)
+returning id, name, email, lang;
@@ -0,0 +1,4 @@
+-- name: GetRateLimitHits :one
+select hits
+from rate_limits
+where key = ? and window_start = ?;
@@ -0,0 +1,9 @@
+-- name: GetUserByAPIToken :one
+select
+    users.id,
+    users.name as username,
+    users.email as user_email,
+    api_tokens.read_only
+from api_tokens
+join users on users.id = api_tokens.user_id
+where api_tokens.token_hash = ?;
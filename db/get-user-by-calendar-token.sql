@@ -0,0 +1,6 @@
+-- name: GetUserByCalendarToken :one
+select id, name, email
+from users
+where calendar_token = ?
+    -- safety check to ensure the given token is not null
+    and calendar_token is not null;
@@ -3,7 +3,9 @@ select
     user_sessions.id,
     user_id,
     users.name as username,
-    users.email as user_email
+    users.email as user_email,
+    user_sessions.logged_in_at,
+    user_sessions.last_used_at
 from user_sessions
 join users on users.id = user_sessions.user_id
 where user_sessions.id = ?;
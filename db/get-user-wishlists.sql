@@ -1,4 +1,4 @@
 -- name: GetUserWishLists :many
-select id, admin_id, name
+select id, admin_id, name, event_date
 from wishlists
 where user_id = ?;
@@ -0,0 +1,5 @@
+-- name: GetWishListWebhooks :many
+select id, url, secret, created_at
+from webhooks
+where wishlist_id = ?
+order by created_at;
@@ -4,6 +4,7 @@ select
     admin_id,
     group_id,
     wishlists.name,
+    event_date,
     users.name as username
 from wishlists
 join users on wishlists.user_id = users.id
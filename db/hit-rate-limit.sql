@@ -0,0 +1,11 @@
+-- name: HitRateLimit :one
+insert into rate_limits (key, window_start, hits)
+values (?, ?, 1)
+on conflict (key) do update set
+    -- the hits of a previous window are forgotten
+    hits = case
+        when rate_limits.window_start = excluded.window_start then rate_limits.hits + 1
+        else 1
+    end,
+    window_start = excluded.window_start
+returning hits;
@@ -0,0 +1,7 @@
+-- name: InsertAPIToken :exec
+insert into api_tokens (
+    id, user_id, name, token_hash, read_only, created_at
+)
+values (
+    ?, ?, ?, ?, ?, ?
+);
@@ -0,0 +1,7 @@
+-- name: InsertOutboxEmail :exec
+insert into email_outbox (
+    id, kind, recipient, lang, payload, status, attempts, next_attempt_at, created_at
+)
+values (
+    ?, ?, ?, ?, ?, 'pending', 0, ?, ?
+);
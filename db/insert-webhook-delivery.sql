@@ -0,0 +1,7 @@
+-- name: InsertWebhookDelivery :exec
+insert into webhook_deliveries (
+    id, webhook_id, event, payload, attempt, status_code, error, created_at
+)
+values (
+    ?, ?, ?, ?, ?, ?, ?, ?
+);
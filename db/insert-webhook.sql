@@ -0,0 +1,7 @@
+-- name: InsertWebhook :exec
+insert into webhooks (
+    id, wishlist_id, url, secret, created_at
+)
+values (
+    ?, ?, ?, ?, ?
+);
@@ -0,0 +1,25 @@
+-- name: InsertWishListChange :exec
+insert into wishlist_changes (
+    id,
+    wishlist_id,
+    kind,
+    element_name,
+    element_description,
+    element_url,
+    created_at,
+    seq
+) values (
+    sqlc.arg(id),
+    sqlc.arg(wishlist_id),
+    sqlc.arg(kind),
+    sqlc.arg(element_name),
+    sqlc.arg(element_description),
+    sqlc.arg(element_url),
+    sqlc.arg(created_at),
+    -- the writes are serialized by SQLite, so the sequence is unique for the list
+    (
+        select coalesce(max(seq), 0) + 1
+        from wishlist_changes
+        where wishlist_id = sqlc.arg(wishlist_id)
+    )
+);
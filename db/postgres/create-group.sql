@@ -0,0 +1,7 @@
+-- name: CreateGroup :exec
+insert into groups (
+    id, name
+)
+values (
+    $1, $2
+);
@@ -0,0 +1,5 @@
+-- name: CreateUserSession :exec
+insert into user_sessions (
+    id, user_id, magic_link_token, magic_link_binding, created_at, last_used_at
+)
+values ($1, $2, $3, $4, $5, $6);
@@ -0,0 +1,7 @@
+-- name: CreateWishList :exec
+insert into wishlists (
+    id, admin_id, name, group_id, user_id, event_date
+)
+values (
+    $1, $2, $3, $4, $5, $6
+);
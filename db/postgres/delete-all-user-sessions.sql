@@ -0,0 +1,2 @@
+-- name: DeleteAllUserSessions :execrows
+delete from user_sessions;
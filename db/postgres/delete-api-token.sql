@@ -0,0 +1,3 @@
+-- name: DeleteAPIToken :execrows
+delete from api_tokens
+where id = $1 and user_id = $2;
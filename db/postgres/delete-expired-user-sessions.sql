@@ -0,0 +1,6 @@
+-- name: DeleteExpiredUserSessions :execrows
+delete from user_sessions
+where (magic_link_token is null and logged_in_at < sqlc.arg('logged_in_before'))
+    or last_used_at < sqlc.arg('last_used_before')
+    -- the magic link was not used in time
+    or (magic_link_token is not null and created_at < sqlc.arg('magic_link_created_before'));
@@ -0,0 +1,3 @@
+-- name: DeleteUserSession :exec
+delete from user_sessions
+where id = $1;
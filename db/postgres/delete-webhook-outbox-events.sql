@@ -0,0 +1,3 @@
+-- name: DeleteWebhookOutboxEvents :exec
+delete from webhook_outbox
+where webhook_id = $1;
@@ -0,0 +1,3 @@
+-- name: DeleteWebhook :execrows
+delete from webhooks
+where id = $1 and wishlist_id = $2;
@@ -0,0 +1,3 @@
+-- name: DeleteWishListChanges :exec
+delete from wishlist_changes
+where wishlist_id = $1;
@@ -0,0 +1,3 @@
+-- name: DeleteWishListElements :exec
+delete from wishlist_elements
+where wishlist_id = $1;
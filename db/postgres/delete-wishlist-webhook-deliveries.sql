@@ -0,0 +1,5 @@
+-- name: DeleteWishListWebhookDeliveries :exec
+delete from webhook_deliveries
+where webhook_id in (
+    select id from webhooks where wishlist_id = $1
+);
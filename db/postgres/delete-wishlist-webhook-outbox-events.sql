@@ -0,0 +1,5 @@
+-- name: DeleteWishListWebhookOutboxEvents :exec
+delete from webhook_outbox
+where webhook_id in (
+    select id from webhooks where wishlist_id = $1
+);
@@ -0,0 +1,3 @@
+-- name: DeleteWishListWebhooks :exec
+delete from webhooks
+where wishlist_id = $1;
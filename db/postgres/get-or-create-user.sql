@@ -0,0 +1,8 @@
+-- name: GetOrCreateUser :one
+insert into users (id, name, email, lang)
+values ($1, $2, $3, $4)
+on conflict (email) do update set
+    name = excluded.name,
+    -- an empty language keeps the known one
+    lang = coalesce(nullif(excluded.lang, ''), users.lang)
+returning id, name, email, lang;
@@ -0,0 +1,4 @@
+-- name: GetRateLimitHits :one
+select hits
+from rate_limits
+where key = $1 and window_start = $2;
@@ -0,0 +1,9 @@
+-- name: GetUserAPITokens :many
+select
+    id,
+    name,
+    read_only,
+    created_at
+from api_tokens
+where user_id = $1
+order by created_at;
@@ -0,0 +1,4 @@
+-- name: GetUserByEmail :one
+select id, name, email
+from users
+where email = $1;
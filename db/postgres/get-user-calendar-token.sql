@@ -0,0 +1,4 @@
+-- name: GetUserCalendarToken :one
+select calendar_token
+from users
+where id = $1;
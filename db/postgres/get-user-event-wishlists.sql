@@ -0,0 +1,11 @@
+-- name: GetUserEventWishLists :many
+select
+    wishlists.id,
+    wishlists.name,
+    event_date,
+    users.name as username
+from wishlists
+join users on wishlists.user_id = users.id
+where user_id = $1
+    and event_date is not null
+order by event_date;
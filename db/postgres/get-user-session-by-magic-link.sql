@@ -0,0 +1,14 @@
+-- name: GetUserSessionByMagicLink :one
+update user_sessions
+set
+    magic_link_token = null,
+    logged_in_at = sqlc.arg('logged_in_at'),
+    last_used_at = sqlc.arg('logged_in_at')
+where magic_link_token = sqlc.arg('magic_link_token')
+    -- safety check to ensure the given token is not null
+    and magic_link_token is not null
+    -- the magic link is used in the browser it was asked from
+    and magic_link_binding = sqlc.arg('magic_link_binding')
+    -- the magic link has not expired
+    and created_at >= sqlc.arg('created_after')
+returning id, user_id, logged_in_at;
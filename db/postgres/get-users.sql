@@ -0,0 +1,10 @@
+-- name: GetUsers :many
+select
+    users.id,
+    users.name,
+    users.email,
+    count(wishlists.id) as lists_count
+from users
+left join wishlists on wishlists.user_id = users.id
+group by users.id
+order by users.email;
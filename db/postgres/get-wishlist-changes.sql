@@ -0,0 +1,12 @@
+-- name: GetWishListChanges :many
+select
+    id,
+    kind,
+    element_name,
+    element_description,
+    element_url,
+    created_at
+from wishlist_changes
+where wishlist_id = $1
+order by created_at desc, seq desc
+limit sqlc.arg('limit')::bigint;
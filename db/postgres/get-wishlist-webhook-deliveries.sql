@@ -0,0 +1,15 @@
+-- name: GetWishListWebhookDeliveries :many
+select
+    webhook_deliveries.id,
+    webhook_id,
+    webhooks.url,
+    event,
+    attempt,
+    status_code,
+    error,
+    webhook_deliveries.created_at
+from webhook_deliveries
+join webhooks on webhooks.id = webhook_deliveries.webhook_id
+where webhooks.wishlist_id = $1
+order by webhook_deliveries.created_at desc
+limit sqlc.arg('limit')::bigint;
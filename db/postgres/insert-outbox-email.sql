@@ -0,0 +1,7 @@
+-- name: InsertOutboxEmail :exec
+insert into email_outbox (
+    id, kind, recipient, lang, payload, status, attempts, next_attempt_at, created_at
+)
+values (
+    $1, $2, $3, $4, $5, 'pending', 0, $6, $7
+);
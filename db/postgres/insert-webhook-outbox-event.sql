@@ -0,0 +1,7 @@
+-- name: InsertWebhookOutboxEvent :exec
+insert into webhook_outbox (
+    id, webhook_id, event, payload, attempts, next_attempt_at, created_at
+)
+values (
+    $1, $2, $3, $4, 0, $5, $6
+);
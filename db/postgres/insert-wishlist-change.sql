@@ -0,0 +1,12 @@
+-- name: InsertWishListChange :exec
+insert into wishlist_changes (
+    id,
+    wishlist_id,
+    kind,
+    element_name,
+    element_description,
+    element_url,
+    created_at
+) values (
+    $1, $2, $3, $4, $5, $6, $7
+);
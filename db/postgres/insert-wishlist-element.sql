@@ -0,0 +1,10 @@
+-- name: InsertWishListElement :exec
+insert into wishlist_elements (
+    id,
+    wishlist_id,
+    name,
+    description,
+    url
+) values (
+    $1, $2, $3, $4, $5
+);
@@ -0,0 +1,4 @@
+-- name: UpdateOutboxEmailAttempt :exec
+update email_outbox
+set status = $1, attempts = $2, next_attempt_at = $3, last_error = $4
+where id = $5;
@@ -0,0 +1,4 @@
+-- name: UpdateWebhookOutboxEventAttempt :exec
+update webhook_outbox
+set attempts = $1, next_attempt_at = $2
+where id = $3;
@@ -0,0 +1,5 @@
+-- name: RetryOutboxEmail :execrows
+update email_outbox
+set status = 'pending', attempts = 0, next_attempt_at = ?
+where id = ?
+    and status = 'failed';
@@ -0,0 +1,4 @@
+-- name: SetUserCalendarToken :exec
+update users
+set calendar_token = ?
+where id = ?;
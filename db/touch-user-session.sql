@@ -0,0 +1,4 @@
+-- name: TouchUserSession :exec
+update user_sessions
+set last_used_at = ?
+where id = ?;
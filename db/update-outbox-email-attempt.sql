@@ -0,0 +1,4 @@
+-- name: UpdateOutboxEmailAttempt :exec
+update email_outbox
+set status = ?, attempts = ?, next_attempt_at = ?, last_error = ?
+where id = ?;
@@ -0,0 +1,4 @@
+-- name: UpdateWebhookOutboxEventAttempt :exec
+update webhook_outbox
+set attempts = ?, next_attempt_at = ?
+where id = ?;
@@ -17,3 +17,33 @@ var ErrWishListUsernameEmpty = errors.New("wishlist username cannot be empty")
 
 // ErrSessionNotFound is returned when a session cannot be found.
 var ErrSessionNotFound = errors.New("session not found")
+
+// ErrLoginBindingMismatch is returned when a magic link is used with another login
+// binding than the one it was asked with, and the login was not confirmed.
+var ErrLoginBindingMismatch = errors.New("magic link asked from another client")
+
+// ErrCalendarNotFound is returned when a calendar token cannot be found.
+var ErrCalendarNotFound = errors.New("calendar not found")
+
+// ErrWebhookNotFound is returned when a webhook cannot be found.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// ErrWebhookInvalidURL is returned when the URL of a webhook is not a valid HTTP URL.
+var ErrWebhookInvalidURL = errors.New("invalid webhook URL")
+
+// ErrWebhookForbiddenAddress is returned when a webhook resolves to an address which is
+// not public, like a loopback or a private one.
+var ErrWebhookForbiddenAddress = errors.New("forbidden webhook address")
+
+// ErrAPITokenNotFound is returned when an API token cannot be found.
+var ErrAPITokenNotFound = errors.New("API token not found")
+
+// ErrAPITokenNameEmpty is returned when the name of an API token is empty.
+var ErrAPITokenNameEmpty = errors.New("API token name cannot be empty")
+
+// ErrUnknownEngine is returned when the configured database engine is not supported.
+var ErrUnknownEngine = errors.New("unknown database engine")
+
+// ErrRateLimited is returned when too many emails were asked for the same recipient or
+// from the same client.
+var ErrRateLimited = errors.New("too many emails sent, try again later")
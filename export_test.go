@@ -0,0 +1,24 @@
+package wishlister
+
+import (
+	"context"
+	"time"
+)
+
+// IsPublicAddress is exported for the tests.
+var IsPublicAddress = isPublicAddress
+
+// StartWebhooks starts the webhooks worker of an app created without DeliverWebhooks,
+// with the given delays, so the tests do not wait for the default ones.
+func StartWebhooks(
+	ctx context.Context,
+	a App,
+	pollInterval time.Duration,
+	retryDelay time.Duration,
+) {
+	webhooksApp := a.(*app)
+	webhooksApp.webhookPollInterval = pollInterval
+	webhooksApp.webhookRetryDelay = retryDelay
+
+	go webhooksApp.runWebhooks(ctx)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
 )
 
 func (a *app) GetWishList(ctx context.Context, listID string) (WishList, error) {
@@ -53,9 +55,10 @@ func (a *app) GetUserWishLists(ctx context.Context, userID string) ([]WishList,
 	var wishLists []WishList
 	for _, listData := range listsData {
 		wishLists = append(wishLists, WishList{
-			ID:      listData.ID,
-			AdminID: listData.AdminID,
-			Name:    listData.Name,
+			ID:        listData.ID,
+			AdminID:   listData.AdminID,
+			Name:      listData.Name,
+			EventDate: parseEventDate(listData.EventDate),
 		})
 	}
 
@@ -89,24 +92,40 @@ func (a *app) getWishList(ctx context.Context, listID string) (WishList, error)
 	}
 
 	wishList := WishList{
-		AdminID:  list.AdminID,
-		ID:       list.ID,
-		Name:     list.Name,
-		GroupID:  list.GroupID.String,
-		Username: list.Username,
+		AdminID:   list.AdminID,
+		ID:        list.ID,
+		Name:      list.Name,
+		GroupID:   list.GroupID.String,
+		Username:  list.Username,
+		EventDate: parseEventDate(list.EventDate),
 	}
 	return wishList, nil
 }
 
 func (a *app) populateElements(ctx context.Context, list *WishList) error {
-	elements, err := a.queries.GetWishListElements(ctx, list.ID)
+	elements, err := getElements(ctx, a.queries, list.ID)
 	if err != nil {
 		return err
 	}
 
-	for _, element := range elements {
-		list.Elements = append(
-			list.Elements,
+	list.Elements = elements
+	return nil
+}
+
+func getElements(
+	ctx context.Context,
+	queries repository.Querier,
+	listID string,
+) ([]WishListElement, error) {
+	elementsData, err := queries.GetWishListElements(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	var elements []WishListElement
+	for _, element := range elementsData {
+		elements = append(
+			elements,
 			WishListElement{
 				Name:        element.Name,
 				Description: element.Description.String,
@@ -115,5 +134,5 @@ func (a *app) populateElements(ctx context.Context, list *WishList) error {
 		)
 	}
 
-	return nil
+	return elements, nil
 }
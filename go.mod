@@ -10,13 +10,17 @@ tool (
 require (
 	github.com/caarlos0/env/v11 v11.4.1
 	github.com/dave/jennifer v1.7.1
+	github.com/emersion/go-msgauth v0.7.0
 	github.com/erdnaxeli/migrator v0.1.0
 	github.com/go-chi/chi/v5 v5.3.1
 	github.com/go-hermes/hermes/v2 v2.6.2
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/go-playground/form v3.1.4+incompatible
 	github.com/go-playground/validator/v10 v10.30.3
 	github.com/google/uuid v1.6.0
+	github.com/jackc/pgx/v5 v5.11.0
 	github.com/matoous/go-nanoid/v2 v2.1.0
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/wneessen/go-mail v0.8.1
 	golang.org/x/text v0.40.0
 	modernc.org/sqlite v1.56.0
@@ -58,7 +62,6 @@ require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
-	github.com/jackc/pgx/v5 v5.9.2 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
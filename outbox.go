@@ -0,0 +1,210 @@
+package wishlister
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	nanoid "github.com/matoous/go-nanoid/v2"
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+// outboxKind is the kind of an email of the outbox.
+type outboxKind string
+
+// outboxWishListCreated is the email sent when a wishlist is created.
+const outboxWishListCreated outboxKind = "wishlist.created"
+
+const (
+	outboxStatusPending = "pending"
+	outboxStatusFailed  = "failed"
+)
+
+const (
+	// outboxBatchSize is the maximum number of emails fetched at once by the worker.
+	outboxBatchSize = 20
+	// outboxPollInterval is the interval at which the worker looks for due emails when
+	// it is not woken up.
+	outboxPollInterval = 30 * time.Second
+	// outboxClaimDuration is how long an email is reserved for the worker sending it. It
+	// must be longer than a delivery, so another worker does not send it twice.
+	outboxClaimDuration = 5 * time.Minute
+)
+
+// outboxWishListCreatedPayload is the payload of an outboxWishListCreated email.
+type outboxWishListCreatedPayload struct {
+	Username string `json:"username"`
+	ListID   string `json:"list_id"`
+	AdminID  string `json:"admin_id"`
+}
+
+// queueEmail adds an email to the outbox, to be sent by the outbox worker.
+//
+// The queries should be run in the transaction of the change the email is about, so
+// the email is sent if and only if the change is saved.
+func queueEmail(
+	ctx context.Context,
+	queries repository.Store,
+	kind outboxKind,
+	to string,
+	lang language.Tag,
+	payload any,
+) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var langTag string
+	if lang != language.Und {
+		langTag = lang.String()
+	}
+
+	emailID, _ := nanoid.New()
+	now := time.Now().Unix()
+
+	return queries.InsertOutboxEmail(ctx, repository.InsertOutboxEmailParams{
+		ID:            emailID,
+		Kind:          string(kind),
+		Recipient:     to,
+		Lang:          langTag,
+		Payload:       string(body),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+}
+
+// wakeUpOutbox makes the outbox worker look for due emails now, instead of waiting
+// for its next poll.
+func (a *app) wakeUpOutbox() {
+	select {
+	case a.outboxWakeUp <- struct{}{}:
+	default:
+		// The worker is already going to wake up.
+	}
+}
+
+// runOutbox sends the emails of the outbox until the context is canceled.
+//
+// A failed email is retried with an exponential backoff, up to outboxAttempts times.
+// It is then marked as failed and kept in the outbox for the operator.
+func (a *app) runOutbox(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		a.sendDueEmails(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-a.outboxWakeUp:
+		}
+	}
+}
+
+// sendDueEmails sends the emails of the outbox that are due, batch by batch.
+func (a *app) sendDueEmails(ctx context.Context) {
+	for {
+		emails, err := a.queries.GetDueOutboxEmails(ctx, repository.GetDueOutboxEmailsParams{
+			NextAttemptAt: time.Now().Unix(),
+			Limit:         outboxBatchSize,
+		})
+		if err != nil {
+			log.Printf("Error while getting outbox emails: %s", err)
+			return
+		}
+
+		for _, email := range emails {
+			a.sendOutboxEmail(ctx, email)
+		}
+
+		if len(emails) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+func (a *app) sendOutboxEmail(ctx context.Context, email repository.GetDueOutboxEmailsRow) {
+	count, err := a.queries.ClaimOutboxEmail(ctx, repository.ClaimOutboxEmailParams{
+		ClaimedUntil:  time.Now().Add(outboxClaimDuration).Unix(),
+		ID:            email.ID,
+		NextAttemptAt: email.NextAttemptAt,
+	})
+	if err != nil {
+		log.Printf("Error while claiming outbox email %s: %s", email.ID, err)
+		return
+	}
+
+	if count == 0 {
+		// Another worker is sending it.
+		return
+	}
+
+	sendErr := a.deliverOutboxEmail(ctx, email)
+	if sendErr == nil {
+		err = a.queries.DeleteOutboxEmail(ctx, email.ID)
+		if err != nil {
+			log.Printf("Error while deleting outbox email %s: %s", email.ID, err)
+		}
+
+		return
+	}
+
+	attempts := email.Attempts + 1
+	params := repository.UpdateOutboxEmailAttemptParams{
+		ID:        email.ID,
+		Status:    outboxStatusPending,
+		Attempts:  attempts,
+		LastError: NewNullString(sendErr.Error()),
+	}
+
+	if attempts >= int64(a.outboxAttempts) {
+		log.Printf("Giving up sending outbox email %s: %s", email.ID, sendErr)
+		params.Status = outboxStatusFailed
+	} else {
+		log.Printf("Error while sending outbox email %s, will retry: %s", email.ID, sendErr)
+	}
+
+	delay := a.outboxRetryDelay << (attempts - 1)
+	params.NextAttemptAt = time.Now().Add(delay).Unix()
+
+	err = a.queries.UpdateOutboxEmailAttempt(ctx, params)
+	if err != nil {
+		log.Printf("Error while updating outbox email %s: %s", email.ID, err)
+	}
+}
+
+func (a *app) deliverOutboxEmail(
+	ctx context.Context,
+	email repository.GetDueOutboxEmailsRow,
+) error {
+	// An unknown language is undefined.
+	lang := language.Make(email.Lang)
+
+	switch outboxKind(email.Kind) {
+	case outboxWishListCreated:
+		var payload outboxWishListCreatedPayload
+		err := json.Unmarshal([]byte(email.Payload), &payload)
+		if err != nil {
+			return err
+		}
+
+		return a.emailSender.SendNewWishListEmail(
+			ctx,
+			email.Recipient,
+			lang,
+			payload.Username,
+			payload.ListID,
+			payload.AdminID,
+		)
+	default:
+		// It may have been queued by a newer version, so it is retried.
+		return fmt.Errorf("unknown email kind %s", email.Kind)
+	}
+}
@@ -0,0 +1,132 @@
+// Package atom implements methods to render the changes of a wishlist as an Atom feed.
+package atom
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
+)
+
+type feed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Author  author   `xml:"author"`
+	Links   []link   `xml:"link"`
+	Entries []entry  `xml:"entry"`
+}
+
+type author struct {
+	Name string `xml:"name"`
+}
+
+type link struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type entry struct {
+	ID      string  `xml:"id"`
+	Title   string  `xml:"title"`
+	Updated string  `xml:"updated"`
+	Links   []link  `xml:"link"`
+	Content content `xml:"content"`
+}
+
+type content struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// WriteWishListChanges writes an Atom feed of the given changes of a wishlist.
+//
+// The listURL and feedURL are respectively the share link of the wishlist and the
+// link of the feed itself. The titles of the entries are written with the given
+// printer.
+func WriteWishListChanges(
+	w io.Writer,
+	list wishlister.WishList,
+	changes []wishlister.WishListChange,
+	printer i18n.Printer,
+	listURL string,
+	feedURL string,
+) error {
+	// The feed must have an updated date, we use the date of the most recent change.
+	// If there is no change, we fall back to the epoch to keep the feed stable.
+	updated := time.Unix(0, 0)
+	if len(changes) > 0 {
+		updated = changes[0].Time
+	}
+
+	f := feed{
+		ID:      fmt.Sprintf("urn:wishlister:list:%s", list.ID),
+		Title:   list.Name,
+		Updated: formatTime(updated),
+		Author:  author{Name: list.Username},
+		Links: []link{
+			{Rel: "alternate", Type: "text/html", Href: listURL},
+			{Rel: "self", Type: "application/atom+xml", Href: feedURL},
+		},
+	}
+
+	for _, change := range changes {
+		f.Entries = append(f.Entries, entry{
+			ID:      fmt.Sprintf("urn:wishlister:change:%s", change.ID),
+			Title:   changeTitle(printer, change),
+			Updated: formatTime(change.Time),
+			Links:   []link{{Rel: "alternate", Type: "text/html", Href: listURL}},
+			Content: content{Type: "text", Body: changeContent(change.Element)},
+		})
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(f)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func changeTitle(printer i18n.Printer, change wishlister.WishListChange) string {
+	switch change.Kind {
+	case wishlister.ChangeAdded:
+		return printer.T("feed.added", change.Element.Name)
+	case wishlister.ChangeRemoved:
+		return printer.T("feed.removed", change.Element.Name)
+	default:
+		return change.Element.Name
+	}
+}
+
+func changeContent(element wishlister.WishListElement) string {
+	var lines []string
+	lines = append(lines, element.Name)
+
+	if element.Description != "" {
+		lines = append(lines, element.Description)
+	}
+
+	if element.URL != "" {
+		lines = append(lines, element.URL)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
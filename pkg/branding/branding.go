@@ -0,0 +1,40 @@
+// Package branding describes the identity of an instance, shown in the pages and the
+// emails.
+package branding
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Branding is the identity of an instance.
+//
+// Only the name is required.
+type Branding struct {
+	// Name is the name of the site.
+	Name string
+	// LogoURL is the URL of an image shown before the name.
+	LogoURL string
+	// PrimaryColor is the main color of the site, as an hexadecimal color like
+	// "#0d6efd".
+	PrimaryColor string
+	// FooterText is shown at the bottom of the pages and the emails.
+	FooterText string
+	// ContactEmail is the address the users can write to.
+	ContactEmail string
+}
+
+// PrimaryColorRGB returns the primary color as comma separated decimal components,
+// like "13, 110, 253", or an empty string if the color is not set or invalid.
+func (b Branding) PrimaryColorRGB() string {
+	if len(b.PrimaryColor) != 7 || b.PrimaryColor[0] != '#' {
+		return ""
+	}
+
+	color, err := strconv.ParseUint(b.PrimaryColor[1:], 16, 32)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d, %d, %d", color>>16, color>>8&0xff, color&0xff)
+}
@@ -0,0 +1,234 @@
+// Package client implements a client for the wishlister JSON API.
+//
+// The Client type implements the wishlister.App interface, so a remote instance can be
+// used in place of the in-process app.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+// Config is the client configuration.
+type Config struct {
+	// BaseURL is the URL of the wishlister instance, like "https://example.org".
+	BaseURL string
+	// Token is a session ID or an API token, used for the calls acting as a user.
+	// It is optional if only wishlists are managed.
+	Token string
+	// HTTPClient is the client used to make the requests. If nil, a client with a
+	// 30 seconds timeout is used.
+	HTTPClient *http.Client
+}
+
+// Client is a client for the wishlister JSON API.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+var _ wishlister.App = (*Client)(nil)
+
+// New returns a new Client.
+func New(config Config) *Client {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(config.BaseURL, "/") + "/api/v1",
+		token:      config.Token,
+		httpClient: httpClient,
+	}
+}
+
+// APIError is an error returned by the API.
+//
+// When the error corresponds to one of the wishlister errors, like
+// wishlister.ErrWishListNotFound, errors.Is can be used to check it.
+type APIError struct {
+	StatusCode int
+	// Code is the stable identifier of the error.
+	Code    string
+	Message string
+	// Fields holds the validation error of each invalid field, if any.
+	Fields map[string]string
+
+	// nameErr is the wishlister error of a missing name, if the request is not about a
+	// wishlist.
+	nameErr error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Unwrap returns the wishlister error corresponding to the error code, if any.
+func (e *APIError) Unwrap() error {
+	switch e.Code {
+	case "wishlist_not_found":
+		return wishlister.ErrWishListNotFound
+	case "invalid_admin_id":
+		return wishlister.ErrWishListInvalidAdminID
+	case "session_not_found":
+		return wishlister.ErrSessionNotFound
+	case "calendar_not_found":
+		return wishlister.ErrCalendarNotFound
+	case "webhook_not_found":
+		return wishlister.ErrWebhookNotFound
+	case "api_token_not_found":
+		return wishlister.ErrAPITokenNotFound
+	case "rate_limited":
+		return wishlister.ErrRateLimited
+	case "validation_error":
+		return e.validationError()
+	default:
+		return nil
+	}
+}
+
+// requiredFieldMessage is the message of the API for a missing field.
+const requiredFieldMessage = "this field is required"
+
+// validationError returns the wishlister error corresponding to the invalid fields, if
+// any.
+func (e *APIError) validationError() error {
+	if e.Fields["name"] == requiredFieldMessage {
+		if e.nameErr != nil {
+			return e.nameErr
+		}
+
+		return wishlister.ErrWishListNameEmpty
+	}
+
+	if e.Fields["username"] == requiredFieldMessage {
+		return wishlister.ErrWishListUsernameEmpty
+	}
+
+	for field := range e.Fields {
+		// The webhook URLs given at the creation of a list are reported as
+		// "webhook_urls[0]".
+		if field == "url" || strings.HasPrefix(field, "webhook_urls[") {
+			return wishlister.ErrWebhookInvalidURL
+		}
+	}
+
+	return nil
+}
+
+// withNameError sets the wishlister error returned for a missing name, if err is an
+// APIError.
+//
+// The API reports all the missing names the same way, whatever the object they name.
+func withNameError(err error, nameErr error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.nameErr = nameErr
+	}
+
+	return err
+}
+
+// do sends a request to the API.
+//
+// If body is not nil, it is sent as JSON. If resp is not nil, the response body is
+// decoded into it. If token is not empty, it is sent as a bearer token.
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	path string,
+	token string,
+	body any,
+	resp any,
+) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		return readAPIError(httpResp)
+	}
+
+	if resp == nil {
+		return nil
+	}
+
+	err = json.NewDecoder(httpResp.Body).Decode(resp)
+	if err != nil {
+		return fmt.Errorf("error while decoding API response: %w", err)
+	}
+
+	return nil
+}
+
+func readAPIError(resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Code    string            `json:"code"`
+			Message string            `json:"message"`
+			Fields  map[string]string `json:"fields"`
+		} `json:"error"`
+	}
+
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	err := json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	apiErr.Code = body.Error.Code
+	apiErr.Message = body.Error.Message
+	apiErr.Fields = body.Error.Fields
+
+	return apiErr
+}
+
+// urlPath joins the given segments into an URL path, escaping each of them.
+func urlPath(segments ...string) string {
+	var builder strings.Builder
+	for _, segment := range segments {
+		builder.WriteString("/")
+		builder.WriteString(url.PathEscape(segment))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,236 @@
+package client_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/client"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+	"github.com/erdnaxeli/wishlister/pkg/server"
+)
+
+// magicLinkSender keeps the magic link tokens instead of sending them.
+type magicLinkSender struct {
+	email.NoMailer
+
+	tokens chan string
+}
+
+func (s magicLinkSender) SendMagicLink(
+	_ context.Context,
+	_ string,
+	_ language.Tag,
+	token string,
+) error {
+	s.tokens <- token
+	return nil
+}
+
+// newClient starts a server backed by a real app, and returns a client for it.
+func newClient(
+	t *testing.T,
+	db *sql.DB,
+	engine wishlister.Engine,
+) (*client.Client, <-chan string) {
+	t.Helper()
+
+	baseURL, tokens := startServer(t, db, engine)
+	return client.New(client.Config{BaseURL: baseURL}), tokens
+}
+
+// startServer starts a server backed by a real app, and returns its URL and the magic
+// link tokens it sends.
+func startServer(t *testing.T, db *sql.DB, engine wishlister.Engine) (string, <-chan string) {
+	t.Helper()
+
+	sender := magicLinkSender{tokens: make(chan string, 1)}
+	app, err := wishlister.NewWithConfig(wishlister.Config{
+		DB:          db,
+		Engine:      engine,
+		EmailSender: sender,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	httpServer := httptest.NewServer(server.New(server.Config{
+		Wishlister: app,
+		BaseURL:    "https://example.org",
+	}))
+	t.Cleanup(httpServer.Close)
+
+	return httpServer.URL, sender.tokens
+}
+
+func TestClientWishList(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		c, _ := newClient(t, db, engine)
+
+		listID, adminID, err := c.CreateWishList(ctx, wishlister.CreateWishlistParams{
+			Name:      "Birthday",
+			Username:  "Alice",
+			EventDate: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = c.UpdateListElements(ctx, listID, adminID, []wishlister.WishListElement{
+			{Name: "Book", URL: "https://example.org/book"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		list, err := c.GetWishList(ctx, listID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if list.Name != "Birthday" || list.Username != "Alice" || list.AdminID != "" {
+			t.Errorf("got list %+v", list)
+		}
+
+		if len(list.Elements) != 1 || list.Elements[0].Name != "Book" {
+			t.Errorf("got elements %+v", list.Elements)
+		}
+
+		_, err = c.GetEditableWishList(ctx, listID, "invalid")
+		if !errors.Is(err, wishlister.ErrWishListInvalidAdminID) {
+			t.Errorf("got %v, expected ErrWishListInvalidAdminID", err)
+		}
+
+		_, err = c.GetWishList(ctx, "unknown")
+		if !errors.Is(err, wishlister.ErrWishListNotFound) {
+			t.Errorf("got %v, expected ErrWishListNotFound", err)
+		}
+	})
+}
+
+func TestClientValidationErrors(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		c, _ := newClient(t, db, engine)
+
+		for _, test := range []struct {
+			params   wishlister.CreateWishlistParams
+			expected error
+		}{
+			{
+				params:   wishlister.CreateWishlistParams{Username: "Alice"},
+				expected: wishlister.ErrWishListNameEmpty,
+			},
+			{
+				params:   wishlister.CreateWishlistParams{Name: "Birthday"},
+				expected: wishlister.ErrWishListUsernameEmpty,
+			},
+			{
+				params: wishlister.CreateWishlistParams{
+					Name:        "Birthday",
+					Username:    "Alice",
+					WebhookURLs: []string{"ftp://example.org"},
+				},
+				expected: wishlister.ErrWebhookInvalidURL,
+			},
+		} {
+			_, _, err := c.CreateWishList(ctx, test.params)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("%+v: got %v, expected %v", test.params, err, test.expected)
+			}
+		}
+
+		listID, adminID, err := c.CreateWishList(ctx, wishlister.CreateWishlistParams{
+			Name:     "Birthday",
+			Username: "Alice",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.AddWebhook(ctx, listID, adminID, "not an URL")
+		if !errors.Is(err, wishlister.ErrWebhookInvalidURL) {
+			t.Errorf("got %v, expected ErrWebhookInvalidURL", err)
+		}
+	})
+}
+
+func TestClientSession(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		c, tokens := newClient(t, db, engine)
+
+		err := c.SendMagicLink(ctx, "alice@example.com", language.English)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		session, err := c.GetSessionByMagicLink(ctx, <-tokens)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if session.SessionID == "" || session.UserID == "" {
+			t.Errorf("got session %+v", session)
+		}
+
+		// The default lifetime is 30 days.
+		if time.Until(session.ExpiresAt) < 29*24*time.Hour {
+			t.Errorf("got a session expiring at %s", session.ExpiresAt)
+		}
+
+		got, err := c.GetSession(ctx, session.SessionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.UserID != session.UserID || !got.ExpiresAt.Equal(session.ExpiresAt) {
+			t.Errorf("got session %+v, expected %+v", got, session)
+		}
+
+		c.DeleteSession(ctx, session.SessionID)
+
+		_, err = c.GetSession(ctx, session.SessionID)
+		if !errors.Is(err, wishlister.ErrSessionNotFound) {
+			t.Errorf("got %v, expected ErrSessionNotFound", err)
+		}
+	})
+}
+
+func TestClientAPITokenBlankName(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		baseURL, tokens := startServer(t, db, engine)
+		c := client.New(client.Config{BaseURL: baseURL})
+
+		err := c.SendMagicLink(ctx, "alice@example.com", language.English)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		session, err := c.GetSessionByMagicLink(ctx, <-tokens)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c = client.New(client.Config{BaseURL: baseURL, Token: session.SessionID})
+		for _, name := range []string{"", "  "} {
+			_, _, err = c.CreateAPIToken(ctx, session.UserID, name, false)
+			if !errors.Is(err, wishlister.ErrAPITokenNameEmpty) {
+				t.Errorf("%q: got %v, expected ErrAPITokenNameEmpty", name, err)
+			}
+
+			if errors.Is(err, wishlister.ErrWishListNameEmpty) {
+				t.Errorf("%q: got ErrWishListNameEmpty", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,332 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+type wishList struct {
+	ID        string    `json:"id"`
+	AdminID   string    `json:"admin_id,omitempty"`
+	Name      string    `json:"name"`
+	GroupID   string    `json:"group_id,omitempty"`
+	Username  string    `json:"username,omitempty"`
+	EventDate string    `json:"event_date,omitempty"`
+	Elements  []element `json:"elements,omitempty"`
+}
+
+type element struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type wishListChange struct {
+	ID      string    `json:"id"`
+	Kind    string    `json:"kind"`
+	Element element   `json:"element"`
+	Time    time.Time `json:"time"`
+}
+
+type webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type webhookDelivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	URL        string    `json:"url"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+type createWishListRequest struct {
+	Name        string   `json:"name"`
+	Username    string   `json:"username"`
+	UserEmail   string   `json:"user_email,omitempty"`
+	EventDate   string   `json:"event_date,omitempty"`
+	Lang        string   `json:"lang,omitempty"`
+	WebhookURLs []string `json:"webhook_urls,omitempty"`
+}
+
+type createWishListResponse struct {
+	ID      string `json:"id"`
+	AdminID string `json:"admin_id"`
+}
+
+type updateListElementsRequest struct {
+	Elements []element `json:"elements"`
+}
+
+type addWebhookRequest struct {
+	URL string `json:"url"`
+}
+
+// CreateWishList creates a new wishlist.
+//
+// Return the wishlist id and the admin id.
+func (c *Client) CreateWishList(
+	ctx context.Context,
+	params wishlister.CreateWishlistParams,
+) (string, string, error) {
+	req := createWishListRequest{
+		Name:        params.Name,
+		Username:    params.Username,
+		UserEmail:   params.UserEmail,
+		WebhookURLs: params.WebhookURLs,
+	}
+	if !params.EventDate.IsZero() {
+		req.EventDate = params.EventDate.Format(time.DateOnly)
+	}
+	if params.Lang != language.Und {
+		req.Lang = params.Lang.String()
+	}
+
+	var resp createWishListResponse
+
+	err := c.do(ctx, http.MethodPost, "/lists", "", req, &resp)
+	if err != nil {
+		return "", "", err
+	}
+
+	return resp.ID, resp.AdminID, nil
+}
+
+// GetWishList returns a wishlist with its elements.
+func (c *Client) GetWishList(ctx context.Context, listID string) (wishlister.WishList, error) {
+	var resp wishList
+
+	err := c.do(ctx, http.MethodGet, urlPath("lists", listID), "", nil, &resp)
+	if err != nil {
+		return wishlister.WishList{}, err
+	}
+
+	return newWishList(resp), nil
+}
+
+// GetEditableWishList returns a wishlist with its elements, checking its admin ID.
+func (c *Client) GetEditableWishList(
+	ctx context.Context,
+	listID string,
+	adminID string,
+) (wishlister.WishList, error) {
+	var resp wishList
+
+	err := c.do(ctx, http.MethodGet, urlPath("lists", listID, adminID), "", nil, &resp)
+	if err != nil {
+		return wishlister.WishList{}, err
+	}
+
+	return newWishList(resp), nil
+}
+
+// UpdateListElements replaces the elements of a wishlist.
+func (c *Client) UpdateListElements(
+	ctx context.Context,
+	listID string,
+	adminID string,
+	elements []wishlister.WishListElement,
+) error {
+	req := updateListElementsRequest{Elements: make([]element, 0, len(elements))}
+	for _, elem := range elements {
+		req.Elements = append(req.Elements, element{
+			Name:        elem.Name,
+			Description: elem.Description,
+			URL:         elem.URL,
+		})
+	}
+
+	return c.do(
+		ctx,
+		http.MethodPut,
+		urlPath("lists", listID, adminID, "elements"),
+		"",
+		req,
+		nil,
+	)
+}
+
+// GetWishListChanges returns the last changes made on the elements of a wishlist, the
+// most recent first.
+func (c *Client) GetWishListChanges(
+	ctx context.Context,
+	listID string,
+) ([]wishlister.WishListChange, error) {
+	var resp []wishListChange
+
+	err := c.do(ctx, http.MethodGet, urlPath("lists", listID, "changes"), "", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]wishlister.WishListChange, 0, len(resp))
+	for _, change := range resp {
+		changes = append(changes, wishlister.WishListChange{
+			ID:      change.ID,
+			Kind:    wishlister.ChangeKind(change.Kind),
+			Element: newElement(change.Element),
+			Time:    change.Time,
+		})
+	}
+
+	return changes, nil
+}
+
+// AddWebhook registers a new webhook on a wishlist.
+func (c *Client) AddWebhook(
+	ctx context.Context,
+	listID string,
+	adminID string,
+	url string,
+) (wishlister.Webhook, error) {
+	var resp webhook
+
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		urlPath("lists", listID, adminID, "webhooks"),
+		"",
+		addWebhookRequest{URL: url},
+		&resp,
+	)
+	if err != nil {
+		return wishlister.Webhook{}, err
+	}
+
+	return newWebhook(resp), nil
+}
+
+// GetWebhooks returns the webhooks registered on a wishlist.
+func (c *Client) GetWebhooks(
+	ctx context.Context,
+	listID string,
+	adminID string,
+) ([]wishlister.Webhook, error) {
+	var resp []webhook
+
+	err := c.do(
+		ctx,
+		http.MethodGet,
+		urlPath("lists", listID, adminID, "webhooks"),
+		"",
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]wishlister.Webhook, 0, len(resp))
+	for _, hook := range resp {
+		webhooks = append(webhooks, newWebhook(hook))
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhookDeliveries returns the last delivery attempts of the webhooks registered
+// on a wishlist, the most recent first.
+func (c *Client) GetWebhookDeliveries(
+	ctx context.Context,
+	listID string,
+	adminID string,
+) ([]wishlister.WebhookDelivery, error) {
+	var resp []webhookDelivery
+
+	err := c.do(
+		ctx,
+		http.MethodGet,
+		urlPath("lists", listID, adminID, "webhooks", "deliveries"),
+		"",
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]wishlister.WebhookDelivery, 0, len(resp))
+	for _, delivery := range resp {
+		deliveries = append(deliveries, wishlister.WebhookDelivery{
+			ID:         delivery.ID,
+			WebhookID:  delivery.WebhookID,
+			URL:        delivery.URL,
+			Event:      wishlister.WebhookEvent(delivery.Event),
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Error:      delivery.Error,
+			Time:       delivery.Time,
+		})
+	}
+
+	return deliveries, nil
+}
+
+// DeleteWebhook deletes a webhook registered on a wishlist.
+func (c *Client) DeleteWebhook(
+	ctx context.Context,
+	listID string,
+	adminID string,
+	webhookID string,
+) error {
+	return c.do(
+		ctx,
+		http.MethodDelete,
+		urlPath("lists", listID, adminID, "webhooks", webhookID),
+		"",
+		nil,
+		nil,
+	)
+}
+
+func newWishList(list wishList) wishlister.WishList {
+	resp := wishlister.WishList{
+		ID:       list.ID,
+		AdminID:  list.AdminID,
+		Name:     list.Name,
+		GroupID:  list.GroupID,
+		Username: list.Username,
+	}
+
+	if list.EventDate != "" {
+		// An invalid date is ignored, like in the database.
+		resp.EventDate, _ = time.Parse(time.DateOnly, list.EventDate)
+	}
+
+	if len(list.Elements) > 0 {
+		resp.Elements = make([]wishlister.WishListElement, 0, len(list.Elements))
+		for _, elem := range list.Elements {
+			resp.Elements = append(resp.Elements, newElement(elem))
+		}
+	}
+
+	return resp
+}
+
+func newElement(elem element) wishlister.WishListElement {
+	return wishlister.WishListElement{
+		Name:        elem.Name,
+		Description: elem.Description,
+		URL:         elem.URL,
+	}
+}
+
+func newWebhook(hook webhook) wishlister.Webhook {
+	return wishlister.Webhook{
+		ID:        hook.ID,
+		URL:       hook.URL,
+		Secret:    hook.Secret,
+		CreatedAt: hook.CreatedAt,
+	}
+}
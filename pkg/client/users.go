@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+type session struct {
+	SessionID string `json:"session_id,omitempty"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username,omitempty"`
+	UserEmail string `json:"user_email,omitempty"`
+	ReadOnly  bool   `json:"read_only"`
+	// ExpiresAt is nil for the sessions opened with an API token.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type apiToken struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ReadOnly  bool      `json:"read_only"`
+	CreatedAt time.Time `json:"created_at"`
+	Token     string    `json:"token,omitempty"`
+}
+
+type createGroupRequest struct {
+	Name      string `json:"name"`
+	UserEmail string `json:"user_email"`
+}
+
+type createGroupResponse struct {
+	ID string `json:"id"`
+}
+
+type sendMagicLinkRequest struct {
+	Email string `json:"email"`
+	Lang  string `json:"lang,omitempty"`
+}
+
+type createSessionRequest struct {
+	MagicLinkToken string `json:"magic_link_token"`
+}
+
+type createAPITokenRequest struct {
+	Name     string `json:"name"`
+	ReadOnly bool   `json:"read_only"`
+}
+
+type calendarToken struct {
+	Token string `json:"token"`
+}
+
+// CreateGroup creates a new group.
+//
+// Return the group id.
+func (c *Client) CreateGroup(
+	ctx context.Context,
+	params wishlister.CreateGroupParams,
+) (string, error) {
+	var resp createGroupResponse
+
+	err := c.do(ctx, http.MethodPost, "/groups", "", createGroupRequest{
+		Name:      params.Name,
+		UserEmail: params.UserEmail,
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.ID, nil
+}
+
+// GetGroup does nothing, as groups cannot be read yet.
+func (c *Client) GetGroup(_ context.Context, _ string) {}
+
+// GetUserWishLists returns all wishlists for a given user.
+//
+// The configured token must belong to this user.
+func (c *Client) GetUserWishLists(
+	ctx context.Context,
+	userID string,
+) ([]wishlister.WishList, error) {
+	return c.getWishLists(ctx, urlPath("users", userID, "lists"), c.token)
+}
+
+// SendMagicLink sends a magic link to the given email address.
+func (c *Client) SendMagicLink(ctx context.Context, email string, lang language.Tag) error {
+	req := sendMagicLinkRequest{Email: email}
+	if lang != language.Und {
+		req.Lang = lang.String()
+	}
+
+	return c.do(ctx, http.MethodPost, "/magic-links", "", req, nil)
+}
+
+// GetSession returns the session with the given session id.
+func (c *Client) GetSession(ctx context.Context, sessionID string) (wishlister.Session, error) {
+	return c.getSession(ctx, sessionID)
+}
+
+// GetSessionByMagicLink returns the session associated with the given magic link
+// token.
+func (c *Client) GetSessionByMagicLink(
+	ctx context.Context,
+	token string,
+) (wishlister.Session, error) {
+	var resp session
+
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		"/sessions",
+		"",
+		createSessionRequest{MagicLinkToken: token},
+		&resp,
+	)
+	if err != nil {
+		return wishlister.Session{}, err
+	}
+
+	return newSession(resp), nil
+}
+
+// DeleteSession deletes the given session.
+//
+// Errors are ignored, as with the in-process app.
+func (c *Client) DeleteSession(ctx context.Context, sessionID string) {
+	_ = c.do(ctx, http.MethodDelete, "/session", sessionID, nil, nil)
+}
+
+// CreateAPIToken creates a new API token for the given user.
+//
+// The configured token must belong to this user.
+func (c *Client) CreateAPIToken(
+	ctx context.Context,
+	userID string,
+	name string,
+	readOnly bool,
+) (wishlister.APIToken, string, error) {
+	var resp apiToken
+
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		urlPath("users", userID, "tokens"),
+		c.token,
+		createAPITokenRequest{Name: name, ReadOnly: readOnly},
+		&resp,
+	)
+	if err != nil {
+		return wishlister.APIToken{}, "", withNameError(err, wishlister.ErrAPITokenNameEmpty)
+	}
+
+	return newAPIToken(resp), resp.Token, nil
+}
+
+// GetAPITokens returns the API tokens of the given user.
+//
+// The configured token must belong to this user.
+func (c *Client) GetAPITokens(ctx context.Context, userID string) ([]wishlister.APIToken, error) {
+	var resp []apiToken
+
+	err := c.do(ctx, http.MethodGet, urlPath("users", userID, "tokens"), c.token, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]wishlister.APIToken, 0, len(resp))
+	for _, token := range resp {
+		tokens = append(tokens, newAPIToken(token))
+	}
+
+	return tokens, nil
+}
+
+// DeleteAPIToken revokes an API token of the given user.
+//
+// The configured token must belong to this user.
+func (c *Client) DeleteAPIToken(ctx context.Context, userID string, tokenID string) error {
+	return c.do(
+		ctx,
+		http.MethodDelete,
+		urlPath("users", userID, "tokens", tokenID),
+		c.token,
+		nil,
+		nil,
+	)
+}
+
+// GetSessionByAPIToken returns a session for the user owning the given API token.
+func (c *Client) GetSessionByAPIToken(
+	ctx context.Context,
+	token string,
+) (wishlister.Session, error) {
+	return c.getSession(ctx, token)
+}
+
+// GetUserCalendarToken returns the calendar token of the given user.
+//
+// The configured token must belong to this user.
+func (c *Client) GetUserCalendarToken(ctx context.Context, userID string) (string, error) {
+	var resp calendarToken
+
+	err := c.do(
+		ctx,
+		http.MethodGet,
+		urlPath("users", userID, "calendar-token"),
+		c.token,
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
+
+// ResetUserCalendarToken generates a new calendar token for the given user.
+//
+// The configured token must belong to this user.
+func (c *Client) ResetUserCalendarToken(ctx context.Context, userID string) (string, error) {
+	var resp calendarToken
+
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		urlPath("users", userID, "calendar-token"),
+		c.token,
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
+
+// GetCalendarWishLists returns the wishlists with an event date of the user owning
+// the given calendar token, ordered by event date.
+func (c *Client) GetCalendarWishLists(
+	ctx context.Context,
+	token string,
+) ([]wishlister.WishList, error) {
+	return c.getWishLists(ctx, urlPath("calendars", token, "lists"), "")
+}
+
+func (c *Client) getSession(ctx context.Context, token string) (wishlister.Session, error) {
+	var resp session
+
+	err := c.do(ctx, http.MethodGet, "/session", token, nil, &resp)
+	if err != nil {
+		return wishlister.Session{}, err
+	}
+
+	return newSession(resp), nil
+}
+
+func (c *Client) getWishLists(
+	ctx context.Context,
+	path string,
+	token string,
+) ([]wishlister.WishList, error) {
+	var resp []wishList
+
+	err := c.do(ctx, http.MethodGet, path, token, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]wishlister.WishList, 0, len(resp))
+	for _, list := range resp {
+		lists = append(lists, newWishList(list))
+	}
+
+	return lists, nil
+}
+
+func newSession(resp session) wishlister.Session {
+	session := wishlister.Session{
+		UserID:    resp.UserID,
+		Username:  resp.Username,
+		UserEmail: resp.UserEmail,
+		SessionID: resp.SessionID,
+		ReadOnly:  resp.ReadOnly,
+	}
+	if resp.ExpiresAt != nil {
+		session.ExpiresAt = *resp.ExpiresAt
+	}
+
+	return session
+}
+
+func newAPIToken(resp apiToken) wishlister.APIToken {
+	return wishlister.APIToken{
+		ID:        resp.ID,
+		Name:      resp.Name,
+		ReadOnly:  resp.ReadOnly,
+		CreatedAt: resp.CreatedAt,
+	}
+}
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+	"github.com/erdnaxeli/wishlister/pkg/migrations"
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+	"github.com/erdnaxeli/wishlister/pkg/repository/postgres"
+)
+
+const adminUsage = `Usage: wishlister-server [command]
+
+Without command, or with "serve", the server is started.
+
+Commands:
+  migrate status               show the applied and pending migrations
+  migrate up                   apply the pending migrations
+  users list                   list all the users
+  user show <email>            show a user and their lists
+  sessions purge [--all|email] delete the expired sessions and magic links, all the
+                               sessions, or only the ones of a user
+  list delete <id>             delete a list with its elements and webhooks
+  send-test-email <address> [lang]
+                               send an email to check the email configuration
+  outbox failed                list the emails that could not be sent
+  outbox retry <id>            send again an email that could not be sent
+  backup [directory]           back up the database (default BACKUP_DIR)
+  restore <file>               replace the database by a backup, the server must be stopped
+`
+
+// ErrUsage is the error when the command line is invalid.
+var ErrUsage = errors.New("invalid usage")
+
+// ErrDatabaseNotUpToDate is the error when an administrative command is run on a
+// database with pending migrations.
+var ErrDatabaseNotUpToDate = errors.New(
+	"the database has pending migrations, run \"migrate up\" first",
+)
+
+// ErrUserNotFound is the error when a user cannot be found.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrOutboxEmailNotFound is the error when a failed email cannot be found in the
+// outbox.
+var ErrOutboxEmailNotFound = errors.New("failed email not found")
+
+// adminCommand is an administrative command.
+type adminCommand struct {
+	run func(ctx context.Context, env adminEnv, args []string) error
+	// minArgs and maxArgs are the bounds of the number of positional arguments.
+	minArgs int
+	maxArgs int
+	// migrations is true if the command manages the migrations itself. Other commands
+	// require an up to date database.
+	migrations bool
+	// email is true if the command needs to send emails.
+	email bool
+	// noDB is true if the command opens the database itself.
+	noDB bool
+}
+
+// adminEnv holds everything needed to run an administrative command.
+type adminEnv struct {
+	cfg     config
+	db      *sql.DB
+	queries repository.Store
+	app     wishlister.App
+	sender  email.Sender
+	out     io.Writer
+}
+
+var adminCommands = map[string]adminCommand{
+	"migrate status":  {run: migrateStatus, migrations: true},
+	"migrate up":      {run: migrateUp, migrations: true},
+	"users list":      {run: listUsers},
+	"user show":       {run: showUser, minArgs: 1, maxArgs: 1},
+	"sessions purge":  {run: purgeSessions, maxArgs: 1},
+	"list delete":     {run: deleteList, minArgs: 1, maxArgs: 1},
+	"send-test-email": {run: sendTestEmail, minArgs: 1, maxArgs: 2, email: true},
+	"outbox failed":   {run: listFailedEmails},
+	"outbox retry":    {run: retryEmail, minArgs: 1, maxArgs: 1},
+	"backup":          {run: backup, maxArgs: 1, migrations: true},
+	"restore":         {run: restore, minArgs: 1, maxArgs: 1, noDB: true},
+}
+
+func runAdmin(ctx context.Context, cfg config, args []string, out io.Writer) error {
+	cmd, args, err := findAdminCommand(args)
+	if err != nil {
+		return err
+	}
+
+	env := adminEnv{cfg: cfg, out: out, sender: email.NoMailer{}}
+	if cmd.email {
+		err = cfg.validateEmail()
+		if err != nil {
+			return err
+		}
+
+		env.sender, err = newMailSender(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.noDB {
+		return cmd.run(ctx, env, args)
+	}
+
+	env.db, err = openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = env.db.Close() }()
+
+	if !cmd.migrations {
+		err = checkMigrations(env.db, cfg.DatabaseEngine)
+		if err != nil {
+			return err
+		}
+	}
+
+	env.queries = repository.NewStore(env.db)
+	if cfg.DatabaseEngine == wishlister.EnginePostgres {
+		env.queries = postgres.NewStore(env.db)
+	}
+
+	env.app, err = wishlister.NewWithConfig(wishlister.Config{
+		DB:          env.db,
+		Engine:      cfg.DatabaseEngine,
+		EmailSender: env.sender,
+	})
+	if err != nil {
+		return err
+	}
+
+	return cmd.run(ctx, env, args)
+}
+
+// findAdminCommand returns the command given by the arguments, and its own
+// arguments.
+func findAdminCommand(args []string) (adminCommand, []string, error) {
+	// Most commands are made of two words.
+	cmd, ok := adminCommand{}, false
+	if len(args) >= 2 {
+		cmd, ok = adminCommands[args[0]+" "+args[1]]
+		if ok {
+			args = args[2:]
+		}
+	}
+
+	if !ok {
+		cmd, ok = adminCommands[args[0]]
+		if !ok {
+			return adminCommand{}, nil, fmt.Errorf("%w: unknown command", ErrUsage)
+		}
+
+		args = args[1:]
+	}
+
+	if len(args) < cmd.minArgs || len(args) > cmd.maxArgs {
+		return adminCommand{}, nil, fmt.Errorf("%w: wrong number of arguments", ErrUsage)
+	}
+
+	return cmd, args, nil
+}
+
+func checkMigrations(db *sql.DB, engine wishlister.Engine) error {
+	migrator, err := migrations.New(db, engine)
+	if err != nil {
+		return err
+	}
+
+	version, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+
+	files, err := migrations.Files(engine)
+	if err != nil {
+		return err
+	}
+
+	if len(files) > 0 && files[len(files)-1].Version != version {
+		return ErrDatabaseNotUpToDate
+	}
+
+	return nil
+}
+
+func migrateStatus(_ context.Context, env adminEnv, _ []string) error {
+	migrator, err := migrations.New(env.db, env.cfg.DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	version, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+
+	files, err := migrations.Files(env.cfg.DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, file := range files {
+		status := "pending"
+		if file.Version <= version {
+			status = "applied"
+		}
+
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", file.Version, file.Name, status)
+	}
+
+	return w.Flush()
+}
+
+func migrateUp(_ context.Context, env adminEnv, _ []string) error {
+	return migrateDB(env.db, env.cfg.DatabaseEngine)
+}
+
+func listUsers(ctx context.Context, env adminEnv, _ []string) error {
+	users, err := env.queries.GetUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tEMAIL\tNAME\tLISTS")
+	for _, user := range users {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", user.ID, user.Email, user.Name, user.ListsCount)
+	}
+
+	return w.Flush()
+}
+
+func showUser(ctx context.Context, env adminEnv, args []string) error {
+	user, err := env.queries.GetUserByEmail(ctx, args[0])
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", ErrUserNotFound, args[0])
+		}
+
+		return err
+	}
+
+	lists, err := env.app.GetUserWishLists(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(
+		env.out,
+		"ID:    %s\nEmail: %s\nName:  %s\n\n",
+		user.ID,
+		user.Email,
+		user.Name,
+	)
+
+	if len(lists) == 0 {
+		_, _ = fmt.Fprintln(env.out, "No lists.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tADMIN ID\tNAME\tDATE")
+	for _, list := range lists {
+		date := ""
+		if !list.EventDate.IsZero() {
+			date = list.EventDate.Format(time.DateOnly)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", list.ID, list.AdminID, list.Name, date)
+	}
+
+	return w.Flush()
+}
+
+// purgeSessions deletes the expired sessions and magic links.
+//
+// With "--all" all the sessions are deleted, and with an email address the ones of this
+// user, which logs them out.
+func purgeSessions(ctx context.Context, env adminEnv, args []string) error {
+	var count int64
+	var err error
+	switch {
+	case len(args) == 0:
+		now := time.Now()
+		count, err = env.queries.DeleteExpiredUserSessions(
+			ctx,
+			repository.DeleteExpiredUserSessionsParams{
+				LoggedInBefore:         now.Add(-env.cfg.SessionLifetime).Unix(),
+				LastUsedBefore:         now.Add(-env.cfg.SessionIdleTimeout).Unix(),
+				MagicLinkCreatedBefore: now.Add(-env.cfg.MagicLinkTTL).Unix(),
+			},
+		)
+	case args[0] == "--all":
+		count, err = env.queries.DeleteAllUserSessions(ctx)
+	default:
+		var user repository.GetUserByEmailRow
+		user, err = env.queries.GetUserByEmail(ctx, args[0])
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %s", ErrUserNotFound, args[0])
+			}
+
+			return err
+		}
+
+		count, err = env.queries.DeleteUserSessions(ctx, user.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.out, "%d sessions deleted.\n", count)
+	return nil
+}
+
+func deleteList(ctx context.Context, env adminEnv, args []string) (err error) {
+	listID := args[0]
+
+	tx, err := env.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// The rows referencing the list are deleted first, the deepest ones before the others,
+	// as PostgreSQL enforces the foreign keys.
+	qtx := env.queries.WithTx(tx)
+	for _, deleteFunc := range []func(context.Context, string) error{
+		qtx.DeleteWishListWebhookOutboxEvents,
+		qtx.DeleteWishListWebhookDeliveries,
+		qtx.DeleteWishListWebhooks,
+		qtx.DeleteWishListChanges,
+		qtx.DeleteWishListElements,
+	} {
+		err = deleteFunc(ctx, listID)
+		if err != nil {
+			return err
+		}
+	}
+
+	count, err := qtx.DeleteWishList(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		err = wishlister.ErrWishListNotFound
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.out, "List %s deleted.\n", listID)
+	return nil
+}
+
+func sendTestEmail(ctx context.Context, env adminEnv, args []string) error {
+	lang := language.Und
+	if len(args) > 1 {
+		var err error
+		lang, err = language.Parse(args[1])
+		if err != nil {
+			return fmt.Errorf("%w: invalid language %s", ErrUsage, args[1])
+		}
+	}
+
+	err := env.sender.SendTestEmail(ctx, args[0], lang)
+	if err != nil {
+		return fmt.Errorf("error while sending test email: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(env.out, "Test email sent to %s.\n", args[0])
+	return nil
+}
+
+func listFailedEmails(ctx context.Context, env adminEnv, _ []string) error {
+	emails, err := env.queries.GetFailedOutboxEmails(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(emails) == 0 {
+		_, _ = fmt.Fprintln(env.out, "No failed emails.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tCREATED\tRECIPIENT\tKIND\tATTEMPTS\tLAST ERROR")
+	for _, email := range emails {
+		_, _ = fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%d\t%s\n",
+			email.ID,
+			time.Unix(email.CreatedAt, 0).Format(time.DateTime),
+			email.Recipient,
+			email.Kind,
+			email.Attempts,
+			email.LastError.String,
+		)
+	}
+
+	return w.Flush()
+}
+
+func retryEmail(ctx context.Context, env adminEnv, args []string) error {
+	count, err := env.queries.RetryOutboxEmail(ctx, repository.RetryOutboxEmailParams{
+		ID:            args[0],
+		NextAttemptAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w: %s", ErrOutboxEmailNotFound, args[0])
+	}
+
+	_, _ = fmt.Fprintf(env.out, "Email %s will be sent again by the server.\n", args[0])
+	return nil
+}
+
+func backup(ctx context.Context, env adminEnv, args []string) error {
+	if env.cfg.DatabaseEngine != wishlister.EngineSQLite {
+		return ErrBackupNotSupported
+	}
+
+	dir := env.cfg.BackupDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	path, err := backupDB(ctx, env.db, dir, env.cfg.BackupKeep)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.out, "Database backed up to %s.\n", path)
+	return nil
+}
+
+func restore(ctx context.Context, env adminEnv, args []string) error {
+	if env.cfg.DatabaseEngine != wishlister.EngineSQLite {
+		return ErrBackupNotSupported
+	}
+
+	err := restoreDB(ctx, args[0], env.cfg.DatabasePath)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.out, "Database restored from %s.\n", args[0])
+	return nil
+}
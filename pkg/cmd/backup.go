@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/migrations"
+)
+
+// backupTimeLayout is the layout of the time in the backup filenames. Its lexical
+// order is the chronological one, and two backups never have the same name.
+const backupTimeLayout = "20060102-150405.000000000"
+
+// ErrBackupNotSupported is the error when backups are used with another engine than
+// SQLite. The tools of the engine, like pg_dump, must be used instead.
+var ErrBackupNotSupported = errors.New("backups are only supported with SQLite")
+
+// ErrBackupCorrupted is the error when a backup to restore fails the integrity check.
+var ErrBackupCorrupted = errors.New("the backup is corrupted")
+
+// ErrBackupInvalidVersion is the error when a backup to restore has no migrations
+// applied, or migrations unknown to this binary.
+var ErrBackupInvalidVersion = errors.New("the backup has an invalid migration version")
+
+// rename renames a file. It is replaced in the tests to make it fail.
+var rename = os.Rename
+
+// backupDB writes a consistent copy of the database in the given directory, then
+// deletes the oldest backups to keep only the given number of them.
+//
+// The database can be used while being backed up. If keep is zero or less, all the
+// backups are kept. Return the path of the new backup.
+func backupDB(ctx context.Context, db *sql.DB, dir string, keep int) (string, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return "", fmt.Errorf("error while creating backup directory: %w", err)
+	}
+
+	path := filepath.Join(dir, "db-"+time.Now().UTC().Format(backupTimeLayout)+".sqlite")
+
+	_, err = db.ExecContext(ctx, "VACUUM INTO ?", path)
+	if err != nil {
+		return "", fmt.Errorf("error while backing up database: %w", err)
+	}
+
+	if keep > 0 {
+		err = pruneBackups(dir, keep)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return path, nil
+}
+
+// pruneBackups deletes the oldest backups of the directory, to keep only the given
+// number of them.
+func pruneBackups(dir string, keep int) error {
+	backups, err := filepath.Glob(filepath.Join(dir, "db-*.sqlite"))
+	if err != nil {
+		return err
+	}
+
+	slices.Sort(backups)
+
+	for len(backups) > keep {
+		err = os.Remove(backups[0])
+		if err != nil {
+			return fmt.Errorf("error while deleting old backup: %w", err)
+		}
+
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// runBackups backs up the database at each interval, until the context is done.
+//
+// Errors are logged, so a failed backup does not stop the next ones.
+func runBackups(ctx context.Context, db *sql.DB, cfg config) {
+	ticker := time.NewTicker(cfg.BackupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			path, err := backupDB(ctx, db, cfg.BackupDir, cfg.BackupKeep)
+			if err != nil {
+				log.Printf("Error during scheduled backup: %s", err)
+				continue
+			}
+
+			log.Printf("Database backed up to %s", path)
+		}
+	}
+}
+
+// restoreDB replaces the database at dbPath by the given backup.
+//
+// The backup must pass the integrity check, and its migration version must be known
+// by this binary. Pending migrations are applied at the next start of the server.
+// The current database is kept next to it with a ".before-restore" suffix. If the
+// restore fails, the current database is left in place.
+//
+// The server must not be running.
+func restoreDB(ctx context.Context, backupPath string, dbPath string) error {
+	err := checkBackup(ctx, backupPath)
+	if err != nil {
+		return err
+	}
+
+	// We first copy the backup next to the database, so the swap is a rename which is
+	// atomic.
+	tmpPath := dbPath + ".restore"
+	err = copyFile(backupPath, tmpPath)
+	if err != nil {
+		return fmt.Errorf("error while copying backup: %w", err)
+	}
+
+	suffix := ".before-restore-" + time.Now().UTC().Format(backupTimeLayout)
+	var moved []string
+	err = func() error {
+		for _, path := range []string{dbPath, dbPath + "-wal", dbPath + "-shm"} {
+			err := rename(path, path+suffix)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+
+				return fmt.Errorf("error while moving current database: %w", err)
+			}
+
+			moved = append(moved, path)
+		}
+
+		err := rename(tmpPath, dbPath)
+		if err != nil {
+			return fmt.Errorf("error while replacing database: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		_ = os.Remove(tmpPath)
+
+		// The files already moved are put back in the reverse order, so the database is
+		// never left without its WAL.
+		for i := len(moved) - 1; i >= 0; i-- {
+			rollbackErr := rename(moved[i]+suffix, moved[i])
+			if rollbackErr != nil {
+				return fmt.Errorf(
+					"%w, and the database could not be put back from %s: %w",
+					err,
+					moved[i]+suffix,
+					rollbackErr,
+				)
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// checkBackup checks the integrity and the migration version of a backup.
+func checkBackup(ctx context.Context, backupPath string) error {
+	_, err := os.Stat(backupPath)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", "file:"+backupPath+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("error while opening backup: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var result string
+	err = db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBackupCorrupted, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("%w: %s", ErrBackupCorrupted, result)
+	}
+
+	var version sql.NullInt64
+	err = db.QueryRowContext(ctx, "SELECT max(version) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBackupInvalidVersion, err)
+	}
+
+	files, err := migrations.Files(wishlister.EngineSQLite)
+	if err != nil {
+		return err
+	}
+
+	if !version.Valid || version.Int64 < 1 ||
+		(len(files) > 0 && version.Int64 > int64(files[len(files)-1].Version)) {
+		return fmt.Errorf("%w: %d", ErrBackupInvalidVersion, version.Int64)
+	}
+
+	return nil
+}
+
+func copyFile(src string, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := out.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		return err
+	}
+
+	return out.Sync()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+// newTestDB returns a migrated SQLite database in a temporary directory, and its path.
+func newTestDB(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "db.sqlite")
+	db, err := openDB(config{DatabaseEngine: wishlister.EngineSQLite, DatabasePath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	err = migrateDB(db, wishlister.EngineSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, path
+}
+
+func TestBackupSameSecond(t *testing.T) {
+	db, _ := newTestDB(t)
+	dir := t.TempDir()
+
+	// The backups are done in a row, so within the same second.
+	paths := map[string]bool{}
+	for range 3 {
+		path, err := backupDB(context.Background(), db, dir, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		paths[path] = true
+	}
+
+	if len(paths) != 3 {
+		t.Errorf("got backups %v, expected 3 different ones", paths)
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "db-*.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(backups) != 2 {
+		t.Errorf("got %d backups kept, expected 2", len(backups))
+	}
+}
+
+func TestRestoreRollback(t *testing.T) {
+	db, dbPath := newTestDB(t)
+	ctx := context.Background()
+
+	backupPath, err := backupDB(ctx, db, t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The database is closed, like when the server is stopped, but its WAL is kept.
+	err = db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{dbPath + "-wal", dbPath + "-shm"} {
+		err = os.WriteFile(path, []byte(path), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	current, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, failing := range []string{dbPath + "-shm", dbPath + ".restore"} {
+		errRename := errors.New("rename failed")
+		rename = func(oldPath string, newPath string) error {
+			if oldPath == failing {
+				return errRename
+			}
+
+			return os.Rename(oldPath, newPath)
+		}
+		t.Cleanup(func() { rename = os.Rename })
+
+		err = restoreDB(ctx, backupPath, dbPath)
+		if !errors.Is(err, errRename) {
+			t.Fatalf("%s: got %v, expected the rename error", failing, err)
+		}
+
+		got, err := os.ReadFile(dbPath)
+		if err != nil {
+			t.Fatalf("%s: %s", failing, err)
+		}
+
+		if string(got) != string(current) {
+			t.Errorf("%s: the database was not put back", failing)
+		}
+
+		for _, path := range []string{dbPath + "-wal", dbPath + "-shm"} {
+			got, err = os.ReadFile(path)
+			if err != nil || string(got) != path {
+				t.Errorf("%s: %s was not put back: %v", failing, path, err)
+			}
+		}
+
+		_, err = os.Stat(dbPath + ".restore")
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s: the copy of the backup was not deleted: %v", failing, err)
+		}
+	}
+
+	rename = os.Rename
+	err = restoreDB(ctx, backupPath, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := filepath.Glob(dbPath + "*.before-restore-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(backups) != 3 {
+		t.Errorf("got %v, expected the database, its WAL and its shared memory", backups)
+	}
+}
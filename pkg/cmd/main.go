@@ -1,31 +1,29 @@
 // Implements a web server that expose a web app to manage wishlists.
+//
+// Without arguments (or with "serve"), it runs the server. Other arguments are
+// administrative commands, see adminUsage.
 package main
 
 import (
+	"context"
 	"database/sql"
-	"embed"
+	"errors"
 	"fmt"
-	"io/fs"
 	"log"
+	"os"
 
 	"github.com/caarlos0/env/v11"
-	"github.com/erdnaxeli/migrator"
 
 	"github.com/erdnaxeli/wishlister"
 	"github.com/erdnaxeli/wishlister/pkg/email"
+	"github.com/erdnaxeli/wishlister/pkg/migrations"
 	"github.com/erdnaxeli/wishlister/pkg/server"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
 
+	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "modernc.org/sqlite"
 )
 
-//go:embed migrations/*.sql
-var migrations embed.FS
-
-type config struct {
-	Email         string `env:"EMAIL"`
-	EmailPassword string `env:"EMAIL_PASSWORD"`
-}
-
 func main() {
 	var cfg config
 	err := env.Parse(&cfg)
@@ -33,76 +31,194 @@ func main() {
 		log.Fatalf("Error while reading configuration: %s", err)
 	}
 
-	if cfg.Email == "" && cfg.EmailPassword == "" {
-		log.Fatalf(
-			"You must provide an email password with the env var EMAIL_PASSWORD or disable emailing with EMAIL=off.",
-		)
+	err = cfg.validate()
+	if err != nil {
+		log.Fatalf("Error while reading configuration:\n%s", err)
 	}
 
-	var mailSender email.Sender
-	if cfg.Email == "off" {
-		mailSender = email.NoMailer{}
-	} else {
-		mailSender, err = email.NewSMTPSender(
-			"contact@malistedevoeux.fr",
-			cfg.EmailPassword,
-			"mail.infomaniak.fr",
-			465,
-			"\"Ma liste de voeux.fr\" <contact@malistedevoeux.fr>",
-		)
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] != "serve" {
+		err = runAdmin(context.Background(), cfg, args, os.Stdout)
 		if err != nil {
-			log.Fatalf("Error while creating mail client: %s", err)
+			if errors.Is(err, ErrUsage) {
+				_, _ = fmt.Fprint(os.Stderr, adminUsage)
+			}
+
+			log.Fatal(err)
 		}
+
+		return
+	}
+
+	err = cfg.validateEmail()
+	if err != nil {
+		log.Fatalf("Error while reading configuration:\n%s", err)
+	}
+
+	mailSender, err := newMailSender(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	db, err := openDB()
+	err = migrateDB(db, cfg.DatabaseEngine)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	err = runServer(db, mailSender)
+	if cfg.BackupInterval > 0 {
+		go runBackups(context.Background(), db, cfg)
+	}
+
+	err = runServer(db, cfg, mailSender)
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
-func openDB() (*sql.DB, error) {
+func newMailSender(cfg config) (email.Sender, error) {
+	switch cfg.Email {
+	case "off":
+		return email.NoMailer{}, nil
+	case "file":
+		mailSender, err := email.NewFileSender(email.FileConfig{
+			Dir:    cfg.EmailDir,
+			Format: cfg.EmailFileFormat,
+			From:   cfg.EmailFrom,
+			DKIM:   cfg.dkim(),
+		}, urls.New(cfg.BaseURL), cfg.branding())
+		if err != nil {
+			return nil, fmt.Errorf("error while creating mail sender: %w", err)
+		}
+
+		return mailSender, nil
+	}
+
+	if cfg.EmailURL != "" {
+		return newTransportSender(cfg)
+	}
+
+	if cfg.SMTPUsername != "" && cfg.EmailPassword == "" {
+		return nil, ErrEmailNotConfigured
+	}
+
+	mailSender, err := email.NewSMTPSender(email.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		TLS:      cfg.SMTPTLS,
+		Username: cfg.SMTPUsername,
+		Password: cfg.EmailPassword,
+		From:     cfg.EmailFrom,
+		DKIM:     cfg.dkim(),
+	}, urls.New(cfg.BaseURL), cfg.branding())
+	if err != nil {
+		return nil, fmt.Errorf("error while creating mail client: %w", err)
+	}
+
+	return mailSender, nil
+}
+
+// newTransportSender returns the sender using the transport URL of the configuration.
+//
+// If the URL has a user without password, the password is EMAIL_PASSWORD, so it does
+// not have to be written in the URL.
+func newTransportSender(cfg config) (email.Sender, error) {
+	transport, err := email.ParseTransportURL(cfg.EmailURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if transport.SMTP.Username != "" && transport.SMTP.Password == "" {
+		if cfg.EmailPassword == "" {
+			return nil, ErrEmailNotConfigured
+		}
+
+		transport.SMTP.Password = cfg.EmailPassword
+	}
+
+	transport.From = cfg.EmailFrom
+	transport.DKIM = cfg.dkim()
+	mailSender, err := email.NewTransportSender(transport, urls.New(cfg.BaseURL), cfg.branding())
+	if err != nil {
+		return nil, fmt.Errorf("error while creating mail client: %w", err)
+	}
+
+	return mailSender, nil
+}
+
+func openDB(cfg config) (*sql.DB, error) {
 	log.Print("Opening database")
 
-	db, err := sql.Open("sqlite", "db.sqlite")
+	var db *sql.DB
+	var err error
+	switch cfg.DatabaseEngine {
+	case wishlister.EngineSQLite:
+		// The outbox and webhooks workers write concurrently to the requests, so a write
+		// waits for the database to be unlocked instead of failing.
+		db, err = sql.Open("sqlite", "file:"+cfg.DatabasePath+"?_pragma=busy_timeout(5000)")
+	case wishlister.EnginePostgres:
+		if cfg.DatabaseURL == "" {
+			return nil, ErrDatabaseURLRequired
+		}
+
+		db, err = sql.Open("pgx", cfg.DatabaseURL)
+	default:
+		return nil, fmt.Errorf("%w: %s", wishlister.ErrUnknownEngine, cfg.DatabaseEngine)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error while opening database: %w", err)
 	}
 
+	return db, nil
+}
+
+func migrateDB(db *sql.DB, engine wishlister.Engine) error {
 	log.Print("Applying migrations")
 
-	subFS, err := fs.Sub(migrations, "migrations")
-	if err != nil {
-		return nil, fmt.Errorf("error while applying migrations: %w", err)
-	}
-	migrator, err := migrator.New(db, subFS)
+	migrator, err := migrations.New(db, engine)
 	if err != nil {
-		return nil, fmt.Errorf("error while applying migrations: %w", err)
+		return fmt.Errorf("error while applying migrations: %w", err)
 	}
 
 	err = migrator.Migrate()
 	if err != nil {
-		return nil, fmt.Errorf("error while applying migrations: %w", err)
+		return fmt.Errorf("error while applying migrations: %w", err)
 	}
 
-	return db, nil
+	return nil
 }
 
-func runServer(db *sql.DB, mailSender email.Sender) error {
+func runServer(db *sql.DB, cfg config, mailSender email.Sender) error {
 	defer func() { _ = db.Close() }()
 	log.Print("Starting application")
 
-	app, err := wishlister.NewWithConfig(db, mailSender)
+	app, err := wishlister.NewWithConfig(wishlister.Config{
+		DB:              db,
+		Engine:          cfg.DatabaseEngine,
+		EmailSender:     mailSender,
+		DeliverEmails:   true,
+		DeliverWebhooks: true,
+
+		WebhookAllowPrivateNetworks: cfg.WebhookAllowPrivateNetworks,
+
+		MagicLinkTTL:       cfg.MagicLinkTTL,
+		SessionLifetime:    cfg.SessionLifetime,
+		SessionIdleTimeout: cfg.SessionIdleTimeout,
+	})
 	if err != nil {
 		return err
 	}
 
-	server.New(server.Config{Wishlister: app}).Run()
-
-	return nil
+	return server.New(server.Config{
+		Wishlister: app,
+		Addr:       cfg.ListenAddr,
+		BaseURL:    cfg.BaseURL,
+		Branding:   cfg.branding(),
+		DevMode:    cfg.DevMode,
+		TrustProxy: cfg.TrustProxy,
+	}).Run()
 }
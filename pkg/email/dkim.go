@@ -0,0 +1,54 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/wneessen/go-mail"
+)
+
+// ErrDKIMIncomplete is the error when only a part of the DKIM configuration is given.
+var ErrDKIMIncomplete = errors.New("DKIM needs a key, a domain and a selector")
+
+// DKIMConfig is the configuration of the DKIM signature of the emails.
+//
+// The public key must be published in the DNS record <selector>._domainkey.<domain>, and
+// the domain should be the one of the sender address.
+type DKIMConfig struct {
+	// KeyPath is the path of the private key, RSA or Ed25519, in PEM format. If it is
+	// empty, the emails are not signed.
+	KeyPath  string
+	Domain   string
+	Selector string
+}
+
+// signer returns the DKIM signer of the configuration, or nil if the emails must not be
+// signed.
+func (c DKIMConfig) signer() (*mail.DKIMSigner, error) {
+	if c.KeyPath == "" && c.Domain == "" && c.Selector == "" {
+		return nil, nil
+	}
+
+	if c.KeyPath == "" || c.Domain == "" || c.Selector == "" {
+		return nil, ErrDKIMIncomplete
+	}
+
+	pemKey, err := os.ReadFile(c.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading DKIM key: %w", err)
+	}
+
+	key, err := mail.PrivKeyFromPEM(pemKey)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading DKIM key: %w", err)
+	}
+
+	signer := mail.NewDKIMSigner(c.Domain, c.Selector, key)
+	err = signer.ValidateConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
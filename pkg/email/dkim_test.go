@@ -0,0 +1,172 @@
+package email_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+// TestDKIM signs an email with each supported key type, and verifies the signature with
+// the public key as it would be published in the DNS.
+func TestDKIM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		key    crypto.Signer
+		record func() (string, error)
+	}{
+		{
+			name: "rsa",
+			key:  rsaKey,
+			record: func() (string, error) {
+				publicKey, err := x509.MarshalPKIXPublicKey(rsaKey.Public())
+				return "v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(publicKey), err
+			},
+		},
+		{
+			name: "ed25519",
+			key:  ed25519Key,
+			record: func() (string, error) {
+				publicKey := ed25519Key.Public().(ed25519.PublicKey)
+				return "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(publicKey), nil
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			msg := sendSignedEmail(t, test.key)
+
+			record, err := test.record()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			verifications, err := dkim.VerifyWithOptions(
+				bytes.NewReader(msg),
+				&dkim.VerifyOptions{
+					LookupTXT: func(domain string) ([]string, error) {
+						if domain != "mail._domainkey.example.org" {
+							t.Errorf("got a lookup of %s", domain)
+						}
+
+						return []string{record}, nil
+					},
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(verifications) != 1 {
+				t.Fatalf("got %d signatures, expected 1", len(verifications))
+			}
+
+			if verifications[0].Err != nil || verifications[0].Domain != "example.org" {
+				t.Errorf("got verification %+v", verifications[0])
+			}
+
+			// A modified email must not be verified.
+			tampered := bytes.Replace(
+				msg,
+				[]byte("alice@example.org"),
+				[]byte("bob@example.org"),
+				1,
+			)
+			verifications, err = dkim.VerifyWithOptions(
+				bytes.NewReader(tampered),
+				&dkim.VerifyOptions{
+					LookupTXT: func(string) ([]string, error) { return []string{record}, nil },
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(verifications) != 1 || verifications[0].Err == nil {
+				t.Errorf("the modified email was verified")
+			}
+		})
+	}
+}
+
+// sendSignedEmail writes an email signed with the given key, and returns it.
+func sendSignedEmail(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "dkim.pem")
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	err = os.WriteFile(keyPath, pemKey, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emailDir := filepath.Join(dir, "emails")
+	sender, err := email.NewFileSender(
+		email.FileConfig{
+			Dir:    emailDir,
+			Format: email.FileFormatEML,
+			From:   "Wishlister <contact@example.org>",
+			DKIM: email.DKIMConfig{
+				KeyPath:  keyPath,
+				Domain:   "example.org",
+				Selector: "mail",
+			},
+		},
+		urls.New("https://example.org"),
+		branding.Branding{Name: "Wishlister"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sender.SendTestEmail(context.Background(), "alice@example.org", language.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(emailDir, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != 1 {
+		t.Fatalf("got %d emails, expected 1", len(paths))
+	}
+
+	msg, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return msg
+}
@@ -0,0 +1,137 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/wneessen/go-mail"
+
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+// FileFormat is the way the emails are written to a directory.
+type FileFormat string
+
+const (
+	// FileFormatMaildir writes the emails in a Maildir, which can be read by most mail
+	// clients. They are delivered in the "new" subdirectory.
+	FileFormatMaildir FileFormat = "maildir"
+	// FileFormatEML writes each email in its own .eml file.
+	FileFormatEML FileFormat = "eml"
+)
+
+// ErrInvalidFileFormat is the error when an unknown file format is given.
+var ErrInvalidFileFormat = errors.New("invalid file format, must be maildir or eml")
+
+// FileConfig is the configuration of the directory the emails are written to.
+type FileConfig struct {
+	// Dir is created if it does not exist.
+	Dir    string
+	Format FileFormat
+	// From is the address of the sender, like "Name <address@example.org>".
+	From string
+	// DKIM is useful to check the signatures before sending real emails.
+	DKIM DKIMConfig
+}
+
+// NewFileSender returns a Sender writing the emails to a directory instead of sending
+// them.
+//
+// The emails are the same as the ones sent by NewSMTPSender, in full MIME format. It is
+// meant for development and tests.
+func NewFileSender(
+	config FileConfig,
+	builder urls.Builder,
+	brand branding.Branding,
+) (Sender, error) {
+	var dirs []string
+	switch config.Format {
+	case FileFormatMaildir:
+		dirs = []string{"tmp", "new", "cur"}
+	case FileFormatEML:
+		dirs = []string{""}
+	default:
+		return sender{}, fmt.Errorf("%w: %s", ErrInvalidFileFormat, config.Format)
+	}
+
+	for _, dir := range dirs {
+		err := os.MkdirAll(filepath.Join(config.Dir, dir), 0o750)
+		if err != nil {
+			return sender{}, err
+		}
+	}
+
+	dkim, err := config.DKIM.signer()
+	if err != nil {
+		return sender{}, err
+	}
+
+	return sender{
+		transport: &fileTransport{dir: config.Dir, format: config.Format},
+		from:      config.From,
+		dkim:      dkim,
+		urls:      builder,
+		brand:     brand,
+	}, nil
+}
+
+// fileTransport writes the emails to a directory.
+type fileTransport struct {
+	dir    string
+	format FileFormat
+	// count is the number of emails written, used to build unique filenames.
+	count atomic.Int64
+}
+
+func (t *fileTransport) deliver(_ context.Context, msg *mail.Msg) error {
+	name := t.uniqueName()
+	tmpPath := filepath.Join(t.dir, "tmp", name)
+	path := filepath.Join(t.dir, "new", name)
+	if t.format == FileFormatEML {
+		tmpPath = filepath.Join(t.dir, "."+name+".eml")
+		path = filepath.Join(t.dir, name+".eml")
+	}
+
+	// The email is written to a temporary file then renamed, so a reader never sees an
+	// incomplete email.
+	err := msg.WriteToFile(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// uniqueName returns a filename following the Maildir conventions, which also sorts the
+// .eml files by time.
+func (t *fileTransport) uniqueName() string {
+	now := time.Now()
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return fmt.Sprintf(
+		"%d.M%06dP%dQ%d.%s",
+		now.Unix(),
+		now.Nanosecond()/1000,
+		os.Getpid(),
+		t.count.Add(1),
+		maildirHostname(hostname),
+	)
+}
+
+// maildirHostname returns the hostname to use in a Maildir filename.
+//
+// A "/" would be read as a directory, and a ":" starts the flags of the email, so they
+// are replaced like the Maildir specification asks to.
+func maildirHostname(hostname string) string {
+	return strings.NewReplacer("/", `\057`, ":", `\072`).Replace(hostname)
+}
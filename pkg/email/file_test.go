@@ -0,0 +1,15 @@
+package email
+
+import "testing"
+
+func TestMaildirHostname(t *testing.T) {
+	for hostname, expected := range map[string]string{
+		"mail.example.org": "mail.example.org",
+		"host/name":        `host\057name`,
+		"host:2":           `host\0722`,
+	} {
+		if got := maildirHostname(hostname); got != expected {
+			t.Errorf("%s: got %s, expected %s", hostname, got, expected)
+		}
+	}
+}
@@ -2,72 +2,57 @@ package email
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/go-hermes/hermes/v2"
-	"github.com/wneessen/go-mail"
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
 )
 
-func (s smtpSender) SendMagicLink(
+func (s sender) SendMagicLink(
 	ctx context.Context,
 	to string,
+	lang language.Tag,
 	magicLink string,
 ) error {
-	htmlBody, textBody, err := s.getMagicLinkMailBody(magicLink)
-	if err != nil {
-		return err
-	}
-
-	mailMsg := mail.NewMsg()
-	err = mailMsg.From(s.from)
-	if err != nil {
-		return err
-	}
-	err = mailMsg.To(to)
-	if err != nil {
-		return err
-	}
-	mailMsg.Subject("Votre lien de connexion")
-	mailMsg.SetBodyString(mail.TypeTextHTML, htmlBody)
-	mailMsg.AddAlternativeString(mail.TypeTextPlain, textBody)
-
-	err = s.client.DialAndSendWithContext(ctx, mailMsg)
+	printer := printerFor(lang)
+	htmlBody, textBody, err := s.getMagicLinkMailBody(printer, magicLink)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.send(ctx, to, printer.T("email.magicLink.subject"), htmlBody, textBody)
 }
 
-func (s smtpSender) getMagicLinkMailBody(
+func (s sender) getMagicLinkMailBody(
+	printer i18n.Printer,
 	sessionID string,
 ) (string, string, error) {
 	mail := hermes.Email{
 		Body: hermes.Body{
-			Greeting: "Bonjour",
+			Greeting: printer.T("email.greeting"),
 			Intros: []string{
-				"Voici votre lien de connexion :",
+				printer.T("email.magicLink.intro"),
 			},
 			Actions: []hermes.Action{
 				{
 					Button: hermes.Button{
-						Text: "Se connecter",
-						Link: fmt.Sprintf(
-							"https://www.malistedevoeux.fr/login/magic/%s",
-							sessionID,
-						),
+						Text:  printer.T("email.magicLink.login"),
+						Link:  s.urls.MagicLink(sessionID),
+						Color: s.brand.PrimaryColor,
 					},
 				},
 			},
 		},
 	}
 
-	htmlBody, err := s.h.GenerateHTML(mail)
+	h := s.hermes(printer)
+	htmlBody, err := h.GenerateHTML(mail)
 	if err != nil {
 		return "", "", err
 	}
 
-	textBody, err := s.h.GeneratePlainText(mail)
+	textBody, err := h.GeneratePlainText(mail)
 	if err != nil {
 		return "", "", err
 	}
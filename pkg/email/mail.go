@@ -3,13 +3,24 @@ package email
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-hermes/hermes/v2"
 	"github.com/wneessen/go-mail"
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
 )
 
 // Sender is the main interface of this package.
+//
+// The emails are written in the given language of the recipient. If it is not
+// supported, or undefined, the default language is used.
 type Sender interface {
 	// SendNewWishListEmail send a mail for a newly created wishlist.
 	//
@@ -17,6 +28,7 @@ type Sender interface {
 	SendNewWishListEmail(
 		ctx context.Context,
 		to string,
+		lang language.Tag,
 		username string,
 		listID string,
 		adminID string,
@@ -25,48 +37,188 @@ type Sender interface {
 	// SendMagicLink sends a magic link to the given email address.
 	//
 	// The link can be used to login the user.
-	SendMagicLink(ctx context.Context, to string, sessionID string) error
+	SendMagicLink(ctx context.Context, to string, lang language.Tag, sessionID string) error
+
+	// SendTestEmail sends an email without any content, to check the configuration.
+	SendTestEmail(ctx context.Context, to string, lang language.Tag) error
+}
+
+// sender builds the emails, and delivers them with its transport.
+type sender struct {
+	transport transport
+	from      string
+	// dkim signs the emails. It is nil if they must not be signed.
+	dkim  *mail.DKIMSigner
+	urls  urls.Builder
+	brand branding.Branding
+}
+
+// transport delivers the built emails.
+type transport interface {
+	deliver(ctx context.Context, msg *mail.Msg) error
 }
 
-type smtpSender struct {
+// smtpTransport sends the emails to a SMTP server.
+type smtpTransport struct {
 	client *mail.Client
-	from   string
+}
+
+func (t smtpTransport) deliver(ctx context.Context, msg *mail.Msg) error {
+	return t.client.DialAndSendWithContext(ctx, msg)
+}
+
+// TLSMode is the way the connection to the SMTP server is secured.
+type TLSMode string
+
+const (
+	// TLSModeSSL uses implicit TLS, usually on port 465.
+	TLSModeSSL TLSMode = "ssl"
+	// TLSModeStartTLS upgrades the connection with STARTTLS, usually on port 587. The
+	// upgrade is mandatory.
+	TLSModeStartTLS TLSMode = "starttls"
+	// TLSModeNone does not secure the connection. It should only be used with a local
+	// server.
+	TLSModeNone TLSMode = "none"
+)
 
-	h hermes.Hermes
+// ErrInvalidTLSMode is the error when an unknown TLS mode is given.
+var ErrInvalidTLSMode = errors.New("invalid TLS mode, must be ssl, starttls or none")
+
+// SMTPConfig is the configuration of the SMTP server.
+type SMTPConfig struct {
+	Host string
+	Port int
+	TLS  TLSMode
+	// Username and Password are used to authenticate. If Username is empty, no
+	// authentication is done.
+	Username string
+	Password string
+	// From is the address of the sender, like "Name <address@example.org>".
+	From string
+	DKIM DKIMConfig
 }
 
 // NewSMTPSender return a Sender object.
+//
+// The links in the emails are built with the given URL builder, and their header and
+// footer show the given branding.
 func NewSMTPSender(
-	username string,
-	password string,
-	server string,
-	port int,
-	from string,
+	config SMTPConfig,
+	builder urls.Builder,
+	brand branding.Branding,
 ) (Sender, error) {
-	client, err := mail.NewClient(
-		server,
-		mail.WithPort(port),
-		mail.WithSSL(),
-		mail.WithUsername(username),
-		mail.WithPassword(password),
-		mail.WithSMTPAuth(mail.SMTPAuthPlain),
-		mail.WithTimeout(10*time.Second),
-	)
+	options := []mail.Option{
+		mail.WithPort(config.Port),
+		mail.WithTimeout(10 * time.Second),
+	}
+
+	switch config.TLS {
+	case TLSModeSSL:
+		options = append(options, mail.WithSSL())
+	case TLSModeStartTLS:
+		options = append(options, mail.WithTLSPolicy(mail.TLSMandatory))
+	case TLSModeNone:
+		options = append(options, mail.WithTLSPolicy(mail.NoTLS))
+	default:
+		return sender{}, fmt.Errorf("%w: %s", ErrInvalidTLSMode, config.TLS)
+	}
+
+	if config.Username != "" {
+		auth := mail.SMTPAuthPlain
+		if config.TLS == TLSModeNone {
+			// The PLAIN mechanism refuses to send the password on a clear connection.
+			auth = mail.SMTPAuthPlainNoEnc
+		}
+
+		options = append(
+			options,
+			mail.WithSMTPAuth(auth),
+			mail.WithUsername(config.Username),
+			mail.WithPassword(config.Password),
+		)
+	}
+
+	client, err := mail.NewClient(config.Host, options...)
 	if err != nil {
-		return smtpSender{}, err
+		return sender{}, err
 	}
 
-	return smtpSender{
-		client: client,
-		from:   from,
-
-		h: hermes.Hermes{
-			Product: hermes.Product{
-				Name:        "Ma liste de vœux .fr",
-				Link:        "https://www.malistdevoeux.fr/",
-				Copyright:   " ",
-				TroubleText: "Si le bouton {ACTION} ne marche pas, copier l'URL suivante dans votre navigateur.",
-			},
-		},
+	dkim, err := config.DKIM.signer()
+	if err != nil {
+		return sender{}, err
+	}
+
+	return sender{
+		transport: smtpTransport{client: client},
+		from:      config.From,
+		dkim:      dkim,
+		urls:      builder,
+		brand:     brand,
 	}, nil
 }
+
+// send builds an email with the given bodies, and delivers it.
+func (s sender) send(
+	ctx context.Context,
+	to string,
+	subject string,
+	htmlBody string,
+	textBody string,
+) error {
+	msg := mail.NewMsg()
+	err := msg.From(s.from)
+	if err != nil {
+		return err
+	}
+
+	err = msg.To(to)
+	if err != nil {
+		return err
+	}
+
+	if s.dkim != nil {
+		msg.SetDKIM(s.dkim)
+	}
+
+	msg.Subject(subject)
+	msg.SetBodyString(mail.TypeTextHTML, htmlBody)
+	msg.AddAlternativeString(mail.TypeTextPlain, textBody)
+
+	return s.transport.deliver(ctx, msg)
+}
+
+// printerFor returns the printer for the given language of a recipient.
+func printerFor(lang language.Tag) i18n.Printer {
+	return i18n.NewPrinter(i18n.Match(lang.String()))
+}
+
+// hermes returns the hermes generator of the emails written by the given printer.
+func (s sender) hermes(printer i18n.Printer) hermes.Hermes {
+	return hermes.Hermes{
+		Product: hermes.Product{
+			Name:        s.brand.Name,
+			Link:        s.urls.Home(),
+			Logo:        s.brand.LogoURL,
+			Copyright:   s.footer(printer),
+			TroubleText: printer.T("email.troubleText"),
+		},
+	}
+}
+
+// footer returns the footer of the emails.
+func (s sender) footer(printer i18n.Printer) string {
+	var parts []string
+	if s.brand.FooterText != "" {
+		parts = append(parts, s.brand.FooterText)
+	}
+	if s.brand.ContactEmail != "" {
+		parts = append(parts, printer.T("email.contact", s.brand.ContactEmail))
+	}
+
+	if len(parts) == 0 {
+		// An empty copyright would be replaced by the hermes one.
+		return " "
+	}
+
+	return strings.Join(parts, " · ")
+}
@@ -3,6 +3,8 @@ package email
 import (
 	"context"
 	"log"
+
+	"golang.org/x/text/language"
 )
 
 // NoMailer does not send any email.
@@ -12,6 +14,7 @@ type NoMailer struct{}
 func (n NoMailer) SendNewWishListEmail(
 	_ context.Context,
 	_ string,
+	_ language.Tag,
 	_ string,
 	_ string,
 	_ string,
@@ -20,7 +23,18 @@ func (n NoMailer) SendNewWishListEmail(
 }
 
 // SendMagicLink actually does not send any email.
-func (n NoMailer) SendMagicLink(_ context.Context, to string, sessionID string) error {
+func (n NoMailer) SendMagicLink(
+	_ context.Context,
+	to string,
+	_ language.Tag,
+	sessionID string,
+) error {
 	log.Printf("NoMailer: SendMagicLink called for %s with sessionID %s", to, sessionID)
 	return nil
 }
+
+// SendTestEmail actually does not send any email.
+func (n NoMailer) SendTestEmail(_ context.Context, to string, _ language.Tag) error {
+	log.Printf("NoMailer: SendTestEmail called for %s", to)
+	return nil
+}
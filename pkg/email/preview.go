@@ -0,0 +1,134 @@
+package email
+
+import (
+	"context"
+	"mime"
+
+	"github.com/wneessen/go-mail"
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+// previewAddress is the sender and the recipient of the previewed emails.
+const previewAddress = "preview@example.org"
+
+// Preview is an email rendered with sample data.
+type Preview struct {
+	// Name identifies the email. It is the name of the Sender method sending it.
+	Name    string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// previewSamples sends each email of the Sender interface with sample data.
+//
+// A new email must be added here to be previewed.
+var previewSamples = []struct {
+	name string
+	send func(ctx context.Context, s Sender, lang language.Tag) error
+}{
+	{
+		name: "SendNewWishListEmail",
+		send: func(ctx context.Context, s Sender, lang language.Tag) error {
+			return s.SendNewWishListEmail(
+				ctx,
+				previewAddress,
+				lang,
+				"Alice",
+				"sample-list-id",
+				"sample-admin-id",
+			)
+		},
+	},
+	{
+		name: "SendMagicLink",
+		send: func(ctx context.Context, s Sender, lang language.Tag) error {
+			return s.SendMagicLink(ctx, previewAddress, lang, "sample-magic-link-token")
+		},
+	},
+	{
+		name: "SendTestEmail",
+		send: func(ctx context.Context, s Sender, lang language.Tag) error {
+			return s.SendTestEmail(ctx, previewAddress, lang)
+		},
+	},
+}
+
+// RenderPreviews returns all the emails of a Sender, rendered with sample data in the
+// given language.
+//
+// The emails are built exactly like the sent ones, with links built with the given URL
+// builder and a header and footer showing the given branding.
+func RenderPreviews(
+	ctx context.Context,
+	builder urls.Builder,
+	brand branding.Branding,
+	lang language.Tag,
+) ([]Preview, error) {
+	transport := &previewTransport{}
+	s := sender{
+		transport: transport,
+		from:      previewAddress,
+		urls:      builder,
+		brand:     brand,
+	}
+
+	previews := make([]Preview, 0, len(previewSamples))
+	for _, sample := range previewSamples {
+		err := sample.send(ctx, s, lang)
+		if err != nil {
+			return nil, err
+		}
+
+		preview, err := newPreview(sample.name, transport.msg)
+		if err != nil {
+			return nil, err
+		}
+
+		previews = append(previews, preview)
+	}
+
+	return previews, nil
+}
+
+func newPreview(name string, msg *mail.Msg) (Preview, error) {
+	preview := Preview{Name: name}
+	subject := msg.GetGenHeader(mail.HeaderSubject)
+	if len(subject) > 0 {
+		// The subject is encoded as soon as it is not ASCII.
+		decoded, err := new(mime.WordDecoder).DecodeHeader(subject[0])
+		if err != nil {
+			return Preview{}, err
+		}
+
+		preview.Subject = decoded
+	}
+
+	for _, part := range msg.GetParts() {
+		content, err := part.GetContent()
+		if err != nil {
+			return Preview{}, err
+		}
+
+		if part.GetContentType() == mail.TypeTextHTML {
+			preview.HTML = string(content)
+		} else if part.GetContentType() == mail.TypeTextPlain {
+			preview.Text = string(content)
+		}
+	}
+
+	return preview, nil
+}
+
+// previewTransport keeps the last email instead of delivering it.
+type previewTransport struct {
+	msg *mail.Msg
+}
+
+func (t *previewTransport) deliver(_ context.Context, msg *mail.Msg) error {
+	t.msg = msg
+	return nil
+}
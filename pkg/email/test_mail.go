@@ -0,0 +1,44 @@
+package email
+
+import (
+	"context"
+
+	"github.com/go-hermes/hermes/v2"
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
+)
+
+func (s sender) SendTestEmail(ctx context.Context, to string, lang language.Tag) error {
+	printer := printerFor(lang)
+	htmlBody, textBody, err := s.getTestMailBody(printer)
+	if err != nil {
+		return err
+	}
+
+	return s.send(ctx, to, printer.T("email.test.subject"), htmlBody, textBody)
+}
+
+func (s sender) getTestMailBody(printer i18n.Printer) (string, string, error) {
+	mail := hermes.Email{
+		Body: hermes.Body{
+			Greeting: printer.T("email.greeting"),
+			Intros: []string{
+				printer.T("email.test.intro"),
+			},
+		},
+	}
+
+	h := s.hermes(printer)
+	htmlBody, err := h.GenerateHTML(mail)
+	if err != nil {
+		return "", "", err
+	}
+
+	textBody, err := h.GeneratePlainText(mail)
+	if err != nil {
+		return "", "", err
+	}
+
+	return htmlBody, textBody, nil
+}
@@ -0,0 +1,164 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wneessen/go-mail"
+
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+// ErrInvalidTransportURL is the error when a transport URL cannot be parsed.
+var ErrInvalidTransportURL = errors.New(
+	"invalid transport URL, must be like smtps://host, smtp+starttls://host, " +
+		"smtp://host or sendmail:///path",
+)
+
+// TransportConfig is the configuration of the way the emails are delivered, as given
+// by a transport URL.
+type TransportConfig struct {
+	// SMTP is the configuration of the SMTP server. It is ignored if SendmailPath is set.
+	SMTP SMTPConfig
+	// SendmailPath is the path of a sendmail binary the emails are given to, instead of
+	// being sent to a SMTP server.
+	SendmailPath string
+	// From is the address of the sender, like "Name <address@example.org>".
+	From string
+	DKIM DKIMConfig
+}
+
+// ParseTransportURL returns the configuration given by a transport URL.
+//
+// The supported URLs are:
+//   - smtps://[user[:password]@]host[:port] uses implicit TLS, on port 465 by default
+//   - smtp+starttls://[user[:password]@]host[:port] upgrades the connection with
+//     STARTTLS, on port 587 by default
+//   - smtp://[user[:password]@]host[:port] does not secure the connection, on port 25
+//     by default, to send to a local relay
+//   - sendmail:///path/to/sendmail gives the emails to a sendmail binary, by default
+//     /usr/sbin/sendmail
+//
+// The user and password must be URL encoded. The From and DKIM fields are not set.
+func ParseTransportURL(rawURL string) (TransportConfig, error) {
+	transportURL, err := url.Parse(rawURL)
+	if err != nil {
+		// The parsing error is not wrapped, as it contains the URL and its password.
+		return TransportConfig{}, ErrInvalidTransportURL
+	}
+
+	if transportURL.RawQuery != "" || transportURL.Fragment != "" {
+		return TransportConfig{}, fmt.Errorf(
+			"%w: query and fragment are not supported",
+			ErrInvalidTransportURL,
+		)
+	}
+
+	var config TransportConfig
+	var defaultPort int
+	switch transportURL.Scheme {
+	case "smtps":
+		config.SMTP.TLS = TLSModeSSL
+		defaultPort = 465
+	case "smtp+starttls":
+		config.SMTP.TLS = TLSModeStartTLS
+		defaultPort = 587
+	case "smtp":
+		config.SMTP.TLS = TLSModeNone
+		defaultPort = 25
+	case "sendmail":
+		if transportURL.Host != "" || transportURL.User != nil {
+			return TransportConfig{}, fmt.Errorf(
+				"%w: sendmail URL must be like sendmail:///path",
+				ErrInvalidTransportURL,
+			)
+		}
+
+		config.SendmailPath = transportURL.Path
+		if config.SendmailPath == "" {
+			config.SendmailPath = mail.SendmailPath
+		}
+
+		return config, nil
+	default:
+		return TransportConfig{}, fmt.Errorf(
+			"%w: unknown scheme %q",
+			ErrInvalidTransportURL,
+			transportURL.Scheme,
+		)
+	}
+
+	config.SMTP.Host = transportURL.Hostname()
+	if config.SMTP.Host == "" {
+		return TransportConfig{}, fmt.Errorf("%w: missing host", ErrInvalidTransportURL)
+	}
+
+	if transportURL.Path != "" && transportURL.Path != "/" {
+		return TransportConfig{}, fmt.Errorf(
+			"%w: path is not supported with SMTP",
+			ErrInvalidTransportURL,
+		)
+	}
+
+	config.SMTP.Port = defaultPort
+	if transportURL.Port() != "" {
+		config.SMTP.Port, err = strconv.Atoi(transportURL.Port())
+		if err != nil || config.SMTP.Port < 1 || config.SMTP.Port > 65535 {
+			return TransportConfig{}, fmt.Errorf("%w: invalid port", ErrInvalidTransportURL)
+		}
+	}
+
+	if transportURL.User != nil {
+		config.SMTP.Username = transportURL.User.Username()
+		config.SMTP.Password, _ = transportURL.User.Password()
+	}
+
+	return config, nil
+}
+
+// NewTransportSender returns a Sender delivering the emails with the given transport.
+//
+// The links in the emails are built with the given URL builder, and their header and
+// footer show the given branding.
+func NewTransportSender(
+	config TransportConfig,
+	builder urls.Builder,
+	brand branding.Branding,
+) (Sender, error) {
+	if config.SendmailPath == "" {
+		config.SMTP.From = config.From
+		config.SMTP.DKIM = config.DKIM
+		return NewSMTPSender(config.SMTP, builder, brand)
+	}
+
+	dkim, err := config.DKIM.signer()
+	if err != nil {
+		return sender{}, err
+	}
+
+	return sender{
+		transport: sendmailTransport{path: config.SendmailPath},
+		from:      config.From,
+		dkim:      dkim,
+		urls:      builder,
+		brand:     brand,
+	}, nil
+}
+
+// sendmailTransport gives the emails to a sendmail binary.
+type sendmailTransport struct {
+	path string
+}
+
+func (t sendmailTransport) deliver(ctx context.Context, msg *mail.Msg) error {
+	// Like for SMTP, a stuck delivery must not block the sender forever.
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return msg.WriteToSendmailWithContext(ctx, t.path)
+}
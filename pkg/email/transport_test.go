@@ -0,0 +1,219 @@
+package email_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
+)
+
+// smtpMessage is an email received by the fake SMTP server.
+type smtpMessage struct {
+	// Auth is the decoded PLAIN authentication, like "\x00user\x00password", or an
+	// empty string if the client did not authenticate.
+	Auth string
+	From string
+	To   []string
+	Data []byte
+}
+
+// startSMTPServer starts a fake SMTP server, without TLS, and returns its address and
+// the messages it receives.
+func startSMTPServer(t *testing.T) (string, <-chan smtpMessage) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	messages := make(chan smtpMessage, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveSMTP(conn, messages)
+		}
+	}()
+
+	return listener.Addr().String(), messages
+}
+
+// serveSMTP speaks just enough SMTP to receive emails.
+func serveSMTP(conn net.Conn, messages chan<- smtpMessage) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP")
+
+	var msg smtpMessage
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250-localhost\r\n250-AUTH PLAIN\r\n250 8BITMIME")
+		case "AUTH":
+			_, credentials, found := strings.Cut(arg, " ")
+			if !found {
+				_ = text.PrintfLine("334 ")
+				credentials, err = text.ReadLine()
+				if err != nil {
+					return
+				}
+			}
+
+			auth, err := base64.StdEncoding.DecodeString(credentials)
+			if err != nil {
+				_ = text.PrintfLine("501 invalid credentials")
+				continue
+			}
+
+			msg.Auth = string(auth)
+			_ = text.PrintfLine("235 authenticated")
+		case "MAIL":
+			msg.From = smtpAddress(arg)
+			_ = text.PrintfLine("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, smtpAddress(arg))
+			_ = text.PrintfLine("250 OK")
+		case "DATA":
+			_ = text.PrintfLine("354 go ahead")
+			msg.Data, err = io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+
+			messages <- msg
+			msg = smtpMessage{Auth: msg.Auth}
+			_ = text.PrintfLine("250 OK")
+		case "RSET", "NOOP":
+			_ = text.PrintfLine("250 OK")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("502 unknown command")
+		}
+	}
+}
+
+// smtpAddress returns the address of a MAIL or RCPT argument, like "FROM:<a@b> SIZE=1".
+func smtpAddress(arg string) string {
+	_, address, _ := strings.Cut(arg, "<")
+	address, _, _ = strings.Cut(address, ">")
+	return address
+}
+
+func receiveEmail(t *testing.T, messages <-chan smtpMessage) smtpMessage {
+	t.Helper()
+
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email received")
+		return smtpMessage{}
+	}
+}
+
+func TestTransportSenderSMTP(t *testing.T) {
+	addr, messages := startSMTPServer(t)
+
+	for _, test := range []struct {
+		url  string
+		auth string
+	}{
+		{url: "smtp://" + addr},
+		{url: "smtp://sender:s%40cret@" + addr, auth: "\x00sender\x00s@cret"},
+	} {
+		config, err := email.ParseTransportURL(test.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		config.From = "Wishlister <contact@example.org>"
+		sender, err := email.NewTransportSender(
+			config,
+			urls.New("https://example.org"),
+			branding.Branding{Name: "Wishlister"},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = sender.SendTestEmail(context.Background(), "alice@example.org", language.English)
+		if err != nil {
+			t.Fatalf("%s: %s", test.url, err)
+		}
+
+		msg := receiveEmail(t, messages)
+		if msg.Auth != test.auth {
+			t.Errorf("%s: got authentication %q, expected %q", test.url, msg.Auth, test.auth)
+		}
+
+		if msg.From != "contact@example.org" ||
+			len(msg.To) != 1 || msg.To[0] != "alice@example.org" {
+			t.Errorf("%s: got envelope from %s to %v", test.url, msg.From, msg.To)
+		}
+
+		for _, header := range []string{
+			`From: "Wishlister" <contact@example.org>`,
+			"To: <alice@example.org>",
+		} {
+			if !bytes.Contains(msg.Data, []byte(header)) {
+				t.Errorf("%s: header %q not found in\n%s", test.url, header, msg.Data)
+			}
+		}
+	}
+}
+
+func TestTransportSenderSMTPRefused(t *testing.T) {
+	// A closed port refuses the connection.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	config, err := email.ParseTransportURL("smtp://" + addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.From = "contact@example.org"
+	sender, err := email.NewTransportSender(
+		config,
+		urls.New("https://example.org"),
+		branding.Branding{Name: "Wishlister"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sender.SendTestEmail(context.Background(), "alice@example.org", language.English)
+	if err == nil {
+		t.Error("the email was sent to a closed port")
+	}
+}
@@ -2,48 +2,32 @@ package email
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/go-hermes/hermes/v2"
-	"github.com/wneessen/go-mail"
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
 )
 
-func (s smtpSender) SendNewWishListEmail(
+func (s sender) SendNewWishListEmail(
 	ctx context.Context,
 	to string,
+	lang language.Tag,
 	username string,
 	listID string,
 	adminID string,
 ) error {
-	htmlBody, textBody, err := s.getNewWishListMailBody(username, listID, adminID)
-	if err != nil {
-		return err
-	}
-
-	mailMsg := mail.NewMsg()
-	err = mailMsg.From(s.from)
-	if err != nil {
-		return err
-	}
-
-	err = mailMsg.To(to)
-	if err != nil {
-		return err
-	}
-
-	mailMsg.Subject("Liste de voeux créé")
-	mailMsg.SetBodyString(mail.TypeTextHTML, htmlBody)
-	mailMsg.AddAlternativeString(mail.TypeTextPlain, textBody)
-
-	err = s.client.DialAndSendWithContext(ctx, mailMsg)
+	printer := printerFor(lang)
+	htmlBody, textBody, err := s.getNewWishListMailBody(printer, username, listID, adminID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.send(ctx, to, printer.T("email.newWishList.subject"), htmlBody, textBody)
 }
 
-func (s smtpSender) getNewWishListMailBody(
+func (s sender) getNewWishListMailBody(
+	printer i18n.Printer,
 	username string,
 	listID string,
 	adminID string,
@@ -51,34 +35,32 @@ func (s smtpSender) getNewWishListMailBody(
 	mail := hermes.Email{
 		Body: hermes.Body{
 			Name:     username,
-			Greeting: "Bonjour",
+			Greeting: printer.T("email.greeting"),
 			Intros: []string{
-				"Votre liste de vœux a bien été créé !",
-				"Voici le lien à partager :",
-				fmt.Sprintf("https://www.malistedevoeux.fr/%s", listID),
+				printer.T("email.newWishList.created"),
+				printer.T("email.newWishList.share"),
+				s.urls.WishList(listID),
 			},
 			Actions: []hermes.Action{
 				{
 					Button: hermes.Button{
-						Text: "Éditer la liste",
-						Link: fmt.Sprintf(
-							"https://www.malistedevoeux.fr/%s/%s/edit",
-							listID,
-							adminID,
-						),
+						Text:  printer.T("email.newWishList.edit"),
+						Link:  s.urls.EditWishList(listID, adminID),
+						Color: s.brand.PrimaryColor,
 					},
 				},
 			},
-			Signature: "À bientôt",
+			Signature: printer.T("email.signature"),
 		},
 	}
 
-	htmlBody, err := s.h.GenerateHTML(mail)
+	h := s.hermes(printer)
+	htmlBody, err := h.GenerateHTML(mail)
 	if err != nil {
 		return "", "", err
 	}
 
-	textBody, err := s.h.GeneratePlainText(mail)
+	textBody, err := h.GeneratePlainText(mail)
 	if err != nil {
 		return "", "", err
 	}
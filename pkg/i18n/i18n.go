@@ -0,0 +1,78 @@
+// Package i18n translates the messages shown to the users.
+//
+// The messages are identified by a key, like "login.title", and translated with
+// golang.org/x/text/message in each of the supported languages.
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Languages are the supported languages. The first one is the default language.
+var Languages = []language.Tag{language.French, language.English}
+
+var (
+	matcher  = language.NewMatcher(Languages)
+	messages = newCatalog()
+)
+
+// Match returns the supported language best matching the given preferences.
+//
+// Each preference is either a language tag, like "en", or the value of an
+// Accept-Language header. The first preferences have the priority. If none of them
+// match, the default language is returned.
+func Match(preferences ...string) language.Tag {
+	_, index := language.MatchStrings(matcher, preferences...)
+	return Languages[index]
+}
+
+// Printer translates the messages in a language.
+type Printer struct {
+	lang    language.Tag
+	printer *message.Printer
+}
+
+// NewPrinter returns a new Printer for the given language, which should be one of
+// Languages.
+func NewPrinter(lang language.Tag) Printer {
+	return Printer{
+		lang:    lang,
+		printer: message.NewPrinter(lang, message.Catalog(messages)),
+	}
+}
+
+// Lang returns the language of the printer, like "fr".
+func (p Printer) Lang() string {
+	return p.lang.String()
+}
+
+// T returns the message of the given key, formatted with the given arguments like
+// fmt.Sprintf.
+func (p Printer) T(key string, args ...any) string {
+	return p.printer.Sprintf(key, args...)
+}
+
+func newCatalog() catalog.Catalog {
+	builder := catalog.NewBuilder(catalog.Fallback(Languages[0]))
+	for _, msg := range catalogMessages {
+		for lang, text := range map[language.Tag]string{
+			language.French:  msg.fr,
+			language.English: msg.en,
+		} {
+			if text == "" {
+				panic(fmt.Sprintf("missing %s translation for message %q", lang, msg.key))
+			}
+
+			err := builder.SetString(lang, msg.key, text)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	return builder
+}
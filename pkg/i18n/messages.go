@@ -0,0 +1,429 @@
+package i18n
+
+// catalogMessages are the translations of all the messages, by key.
+//
+// Every message must be translated in all the languages.
+var catalogMessages = []struct {
+	key string
+	fr  string
+	en  string
+}{
+	// Common
+	{"common.backHome", "Retourner à l'accueil", "Back to the home page"},
+	{"common.create", "Créer", "Create"},
+	{"common.dateLayout", "02/01/2006", "01/02/2006"},
+	{"common.dateTimeLayout", "02/01/2006 15:04:05", "01/02/2006 15:04:05"},
+	{"common.delete", "Supprimer", "Delete"},
+	{"common.error", "Erreur", "Error"},
+	{
+		"common.formError",
+		"Erreur lors de la soumission du formulaire, veuillez réessayer.",
+		"Error while submitting the form, please try again.",
+	},
+	{"notFound.message", "Page inconnue", "Unknown page"},
+
+	// Base template
+	{"base.contact", "Contact :", "Contact:"},
+	{"base.language", "Langue", "Language"},
+	{"base.myLists", "Mes listes de vœux", "My wishlists"},
+
+	// Home page
+	{"index.list.title", "Liste de vœux", "Wishlist"},
+	{
+		"index.list.description",
+		"Vous pouvez créer une liste et y ajouter des éléments que vous souhaitez recevoir " +
+			"en cadeau.",
+		"You can create a list and add to it the things you would like to receive as a gift.",
+	},
+	{
+		"index.list.sharing",
+		"Cette liste pourra être consultée par toute personne disposant du lien.",
+		"Anyone with the link will be able to see this list.",
+	},
+	{"index.list.create", "Créer une nouvelle liste de vœux", "Create a new wishlist"},
+	{"index.group.title", "Groupe (fonctionnalité à venir)", "Group (coming soon)"},
+	{
+		"index.group.description",
+		"Vous pouvez aussi créer un groupe.",
+		"You can also create a group.",
+	},
+	{
+		"index.group.members",
+		"Chaque personne invitée dans le groupe via son adresse email pourra créer sa propre " +
+			"liste de vœux et voir celles des autres membres du groupe.",
+		"Each person invited to the group by email will be able to create their own wishlist " +
+			"and see the ones of the other members of the group.",
+	},
+	{"index.group.create", "Créer un groupe", "Create a group"},
+
+	// New wishlist
+	{"new.title", "Créer une liste de vœux", "Create a wishlist"},
+	{"new.name", "Nom de la liste", "Name of the list"},
+	{"new.name.placeholder", "Pour mon anniversaire", "For my birthday"},
+	{"new.name.required", "Le nom est requis.", "The name is required."},
+	{
+		"new.name.max",
+		"Le nom doit faire moins de 255 caractères.",
+		"The name must be less than 255 characters long.",
+	},
+	{
+		"new.name.invalid",
+		"Le nom est requis et doit faire moins de 255 caractères.",
+		"The name is required and must be less than 255 characters long.",
+	},
+	{"new.user", "Nom d'utilisateur", "Username"},
+	{"new.user.required", "Le nom d'utilisateur est requis.", "The username is required."},
+	{
+		"new.user.max",
+		"Le nom d'utilisateur doit faire moins de 255 caractères.",
+		"The username must be less than 255 characters long.",
+	},
+	{
+		"new.user.invalid",
+		"Le nom d'utilisateur est requis et doit faire moins de 255 caractères.",
+		"The username is required and must be less than 255 characters long.",
+	},
+	{"new.email", "Adresse email (optionnel)", "Email address (optional)"},
+	{
+		"new.email.help",
+		"Cela permet de recevoir le lien de la liste de vœux par email et de la retrouver si " +
+			"vous l'avez perdue.",
+		"It allows you to receive the link of the wishlist by email and to find it again if " +
+			"you lost it.",
+	},
+	{"new.email.invalid", "L'adresse email n'est pas valide.", "The email address is not valid."},
+	{
+		"new.email.max",
+		"L'adresse email doit faire moins de 255 caractères.",
+		"The email address must be less than 255 characters long.",
+	},
+	{
+		"new.email.error",
+		"L'adresse email est requise et doit faire moins de 255 caractères.",
+		"The email address is required and must be less than 255 characters long.",
+	},
+	{
+		"new.rateLimited",
+		"Trop d'emails ont été envoyés à cette adresse ou depuis votre connexion. " +
+			"Veuillez réessayer dans une heure, ou créer la liste sans adresse email.",
+		"Too many emails were sent to this address or from your connection. " +
+			"Please try again in an hour, or create the list without an email address.",
+	},
+	{"new.eventDate", "Date de l'événement (optionnel)", "Date of the event (optional)"},
+	{
+		"new.eventDate.help",
+		"Anniversaire, Noël… La liste apparaîtra à cette date dans votre agenda si vous y " +
+			"êtes abonné.",
+		"Birthday, Christmas… The list will show up at this date in your calendar if you " +
+			"subscribed to it.",
+	},
+	{"new.eventDate.invalid", "La date n'est pas valide.", "The date is not valid."},
+
+	// New group
+	{"newGroup.title", "Créer un groupe", "Create a group"},
+	{"newGroup.name", "Nom du groupe", "Name of the group"},
+	{"newGroup.user", "Votre nom d'utilisateur", "Your username"},
+	{"newGroup.email", "Votre adresse email", "Your email address"},
+	{"newGroup.created", "Le groupe %s a été créé.", "The group %s has been created."},
+	{
+		"newGroup.email.help",
+		"Cela permet de recevoir le lien d'administration du groupe par email et de le " +
+			"retrouver si vous l'avez perdu.",
+		"It allows you to receive the administration link of the group by email and to find " +
+			"it again if you lost it.",
+	},
+
+	// Wishlist
+	{"listView.by", "de %s", "by %s"},
+	{
+		"listView.follow.help",
+		"Suivre les changements de la liste dans un lecteur de flux",
+		"Follow the changes of the list in a feed reader",
+	},
+	{"listView.follow", "suivre", "follow"},
+	{"listView.print", "imprimer", "print"},
+	{"listView.webhooks", "webhooks", "webhooks"},
+	{"listView.edit", "éditer", "edit"},
+	{"listView.eventDate", "Pour le %s", "For %s"},
+	{"listView.group", "Cette liste fait partie d'un groupe.", "This list is part of a group."},
+	{"listView.shareURL", "Lien à partager :", "Link to share:"},
+	{"listView.adminURL", "Lien d'administration :", "Administration link:"},
+	{"listView.openLink", "ouvrir le lien", "open the link"},
+	{"listNotFound.message", "La liste n'a pas pu être trouvée.", "The list could not be found."},
+	{
+		"listAccessDenied.message",
+		"L'URL est incorrecte, vous ne pouvez pas éditer cette liste de vœux. Vous pouvez " +
+			"cependant",
+		"The URL is incorrect, you cannot edit this wishlist. You can however",
+	},
+	{"listAccessDenied.view", "la consulter", "see it"},
+
+	// Wishlist edition
+	{"listEdit.title", "Éditer la liste de vœux \"%s\"", "Edit the wishlist \"%s\""},
+	{"listEdit.name", "Nom", "Name"},
+	{"listEdit.name.required", "Le nom ne peut pas être vide.", "The name cannot be empty."},
+	{
+		"listEdit.name.max",
+		"Le nom ne peut pas dépasser 255 caractères.",
+		"The name cannot be longer than 255 characters.",
+	},
+	{"listEdit.description", "Description (optionnel)", "Description (optional)"},
+	{
+		"listEdit.description.max",
+		"La description ne peut pas dépasser 500 caractères.",
+		"The description cannot be longer than 500 characters.",
+	},
+	{"listEdit.url", "Lien vers l'article (optionnel)", "Link to the item (optional)"},
+	{"listEdit.url.invalid", "L'URL n'est pas valide.", "The URL is not valid."},
+	{
+		"listEdit.url.max",
+		"L'URL ne peut pas dépasser 2000 caractères.",
+		"The URL cannot be longer than 2000 characters.",
+	},
+	{"listEdit.deleteElement", "Supprimer l'élément", "Delete the item"},
+	{"listEdit.addElement", "Ajouter un nouvel élément", "Add a new item"},
+	{"listEdit.save", "Enregistrer", "Save"},
+
+	// Wishlist webhooks
+	{"listWebhooks.title", "Webhooks de la liste \"%s\"", "Webhooks of the list \"%s\""},
+	{"listWebhooks.back", "retour à la liste", "back to the list"},
+	{
+		"listWebhooks.help",
+		"Les webhooks reçoivent une requête POST avec un contenu JSON à chaque modification " +
+			"de la liste. Chaque requête est signée avec le secret du webhook : l'en-tête",
+		"The webhooks receive a POST request with a JSON body on each change of the list. " +
+			"Each request is signed with the secret of the webhook: the header",
+	},
+	{"listWebhooks.help.contains", "contient", "contains"},
+	{
+		"listWebhooks.help.hmac",
+		"suivi du HMAC-SHA256 du contenu.",
+		"followed by the HMAC-SHA256 of the body.",
+	},
+	{"listWebhooks.secret", "Secret :", "Secret:"},
+	{"listWebhooks.empty", "Aucun webhook pour le moment.", "No webhooks yet."},
+	{"listWebhooks.url", "URL du webhook", "URL of the webhook"},
+	{"listWebhooks.url.invalid", "L'URL n'est pas valide.", "The URL is not valid."},
+	{"listWebhooks.add", "Ajouter", "Add"},
+	{"listWebhooks.deliveries", "Derniers envois", "Last deliveries"},
+	{"listWebhooks.deliveries.date", "Date", "Date"},
+	{"listWebhooks.deliveries.url", "URL", "URL"},
+	{"listWebhooks.deliveries.event", "Événement", "Event"},
+	{"listWebhooks.deliveries.attempt", "Essai", "Attempt"},
+	{"listWebhooks.deliveries.result", "Résultat", "Result"},
+
+	// Feeds and exports
+	{
+		"calendar.error",
+		"Erreur lors de la génération du calendrier.",
+		"Error while generating the calendar.",
+	},
+	{"calendar.summary", "%s (liste de vœux de %s)", "%s (%s's wishlist)"},
+	{"calendar.description", "Lien de la liste de vœux : %s", "Link of the wishlist: %s"},
+	{"calendar.reminder", "Consulter la liste de vœux : %s", "Check the wishlist: %s"},
+	{"feed.error", "Erreur lors de la génération du flux.", "Error while generating the feed."},
+	{"feed.added", "Ajouté : %s", "Added: %s"},
+	{"feed.removed", "Retiré : %s", "Removed: %s"},
+	{"print.error", "Erreur lors de la génération du PDF.", "Error while generating the PDF."},
+	{"print.owner", "Liste de vœux de %s", "%s's wishlist"},
+	{"print.empty", "Cette liste est vide.", "This list is empty."},
+
+	// Login
+	{"login.title", "Connexion par lien magique", "Log in with a magic link"},
+	{
+		"login.description",
+		"Entrez votre adresse email. Vous recevrez un lien magique vous permettant de vous " +
+			"authentifier sans mot de passe.",
+		"Enter your email address. You will receive a magic link allowing you to log in " +
+			"without a password.",
+	},
+	{
+		"login.sent",
+		"Un email contenant le lien de connexion a été envoyé à %s.",
+		"An email with the login link has been sent to %s.",
+	},
+	{"login.email", "Adresse email", "Email address"},
+	{
+		"login.email.help",
+		"Un lien de connexion sera envoyé à cette adresse.",
+		"A login link will be sent to this address.",
+	},
+	{"login.email.required", "L'adresse email est requise.", "The email address is required."},
+	{"login.email.invalid", "L'adresse email n'est pas valide.", "The email address is not valid."},
+	{"login.email.max", "L'adresse email est trop longue.", "The email address is too long."},
+	{"login.submit", "Envoyer le lien magique", "Send the magic link"},
+	{
+		"login.sendError",
+		"Erreur lors de l'envoi du lien magique, veuillez réessayer.",
+		"Error while sending the magic link, please try again.",
+	},
+	{
+		"login.rateLimited",
+		"Trop de liens ont été demandés. Veuillez réessayer dans une heure.",
+		"Too many links were asked for. Please try again in an hour.",
+	},
+	{
+		"login.magicLinkInvalid",
+		"Le lien magique est invalide ou a expiré. Veuillez demander un nouveau lien.",
+		"The magic link is invalid or has expired. Please ask for a new link.",
+	},
+	{"loginMagic.title", "Connexion", "Log in"},
+	{
+		"loginMagic.description",
+		"Cliquez sur le bouton ci-dessous pour vous connecter.",
+		"Click the button below to log in.",
+	},
+	{"loginMagic.submit", "Se connecter", "Log in"},
+	{
+		"loginMagic.otherBrowser",
+		"Ce lien a été demandé depuis un autre navigateur. Ne continuez que si c'est vous " +
+			"qui l'avez demandé : vous serez connecté au compte de cette adresse email.",
+		"This link was asked from another browser. Only continue if you asked for it: " +
+			"you will be logged in to the account of this email address.",
+	},
+	{"loginMagic.confirm", "Oui, me connecter", "Yes, log me in"},
+
+	// Logout
+	{"logout.title", "Vous êtes déconnecté", "You are logged out"},
+	{
+		"logout.message",
+		"Vous avez été déconnecté avec succès. À bientôt !",
+		"You have been logged out successfully. See you soon!",
+	},
+	{"logout.backHome", "Retour à l'accueil", "Back to the home page"},
+
+	// User wishlists
+	{"userLists.title", "Mes listes de vœux", "My wishlists"},
+	{"userLists.new", "Nouvelle liste", "New list"},
+	{"userLists.logout", "Se déconnecter", "Log out"},
+	{
+		"userLists.error",
+		"Erreur lors de la récupération de vos listes de souhaits. " +
+			"Veuillez réessayer plus tard.",
+		"Error while getting your wishlists. Please try again later.",
+	},
+	{"userLists.empty", "Vous n'avez aucune liste pour le moment.", "You don't have any list yet."},
+	{"userLists.name", "Nom", "Name"},
+	{"userLists.date", "Date", "Date"},
+	{"userLists.actions", "Actions", "Actions"},
+	{"userLists.view", "Voir la liste", "See the list"},
+	{"userLists.edit", "Éditer", "Edit"},
+	{"userLists.calendar", "Agenda", "Calendar"},
+	{
+		"userLists.calendar.help",
+		"Abonnez-vous à ce lien depuis votre application d'agenda pour voir vos listes ayant " +
+			"une date d'événement. Ce lien est secret, ne le partagez pas.",
+		"Subscribe to this link from your calendar app to see your lists having an event " +
+			"date. This link is secret, do not share it.",
+	},
+	{"userLists.calendar.reset", "Générer un nouveau lien", "Generate a new link"},
+	{"userLists.calendar.create", "Créer un lien d'agenda", "Create a calendar link"},
+	{
+		"userLists.calendar.error",
+		"Erreur lors de la génération du lien d'agenda. Veuillez réessayer plus tard.",
+		"Error while generating the calendar link. Please try again later.",
+	},
+	{"userLists.api", "API", "API"},
+	{
+		"userLists.api.help",
+		"Créez des jetons pour utiliser l'API depuis vos scripts.",
+		"Create tokens to use the API from your scripts.",
+	},
+	{"userLists.api.tokens", "Gérer mes jetons", "Manage my tokens"},
+
+	// API tokens
+	{"userTokens.title", "Jetons d'API", "API tokens"},
+	{"userTokens.back", "retour à mes listes", "back to my lists"},
+	{
+		"userTokens.help",
+		"Les jetons d'API permettent à vos scripts d'utiliser l'API en votre nom, en les " +
+			"envoyant dans l'en-tête",
+		"The API tokens allow your scripts to use the API on your behalf, by sending them in " +
+			"the header",
+	},
+	{"userTokens.help.token", "jeton", "token"},
+	{
+		"userTokens.help.readOnly",
+		"Un jeton en lecture seule ne peut rien modifier.",
+		"A read-only token cannot change anything.",
+	},
+	{
+		"userTokens.created",
+		"Votre jeton a été créé. Copiez-le maintenant, il ne sera plus affiché :",
+		"Your token has been created. Copy it now, it will not be shown again:",
+	},
+	{"userTokens.readOnly.badge", "lecture seule", "read-only"},
+	{"userTokens.createdAt", "Créé le %s", "Created on %s"},
+	{"userTokens.revoke", "Révoquer", "Revoke"},
+	{
+		"userTokens.empty",
+		"Vous n'avez aucun jeton pour le moment.",
+		"You don't have any token yet.",
+	},
+	{"userTokens.name", "Nom du jeton", "Name of the token"},
+	{"userTokens.name.placeholder", "Mon script", "My script"},
+	{
+		"userTokens.name.invalid",
+		"Le nom est requis et ne doit pas dépasser 255 caractères.",
+		"The name is required and must not be longer than 255 characters.",
+	},
+	{"userTokens.readOnly", "Lecture seule", "Read-only"},
+	{
+		"userTokens.createError",
+		"Erreur lors de la création du jeton, veuillez réessayer.",
+		"Error while creating the token, please try again.",
+	},
+	{
+		"userTokens.deleteError",
+		"Erreur lors de la suppression du jeton, veuillez réessayer.",
+		"Error while deleting the token, please try again.",
+	},
+	{
+		"userTokens.listError",
+		"Erreur lors de la récupération de vos jetons. Veuillez réessayer plus tard.",
+		"Error while getting your tokens. Please try again later.",
+	},
+
+	// Emails
+	{"email.greeting", "Bonjour", "Hello"},
+	{"email.signature", "À bientôt", "See you soon"},
+	{"email.contact", "Contact : %s", "Contact: %s"},
+	{
+		"email.troubleText",
+		"Si le bouton {ACTION} ne marche pas, copier l'URL suivante dans votre navigateur.",
+		"If the {ACTION} button does not work, copy the following URL into your browser.",
+	},
+	{"email.newWishList.subject", "Liste de vœux créée", "Wishlist created"},
+	{
+		"email.newWishList.created",
+		"Votre liste de vœux a bien été créée !",
+		"Your wishlist has been created!",
+	},
+	{"email.newWishList.share", "Voici le lien à partager :", "Here is the link to share:"},
+	{"email.newWishList.edit", "Éditer la liste", "Edit the list"},
+	{"email.magicLink.subject", "Votre lien de connexion", "Your login link"},
+	{"email.magicLink.intro", "Voici votre lien de connexion :", "Here is your login link:"},
+	{"email.magicLink.login", "Se connecter", "Log in"},
+	{"email.test.subject", "Email de test", "Test email"},
+	{
+		"email.test.intro",
+		"Ceci est un email de test : l'envoi des emails fonctionne.",
+		"This is a test email: sending emails works.",
+	},
+
+	// Dev email previews
+	{"devEmails.title", "Aperçu des emails", "Email previews"},
+	{
+		"devEmails.help",
+		"Les emails sont rendus avec des données d'exemple, dans la langue de la page.",
+		"The emails are rendered with sample data, in the language of the page.",
+	},
+	{"devEmails.subject", "Sujet : %s", "Subject: %s"},
+	{"devEmails.html", "HTML", "HTML"},
+	{"devEmails.text", "Texte", "Text"},
+	{
+		"devEmails.error",
+		"Erreur lors du rendu des emails : %s",
+		"Error while rendering the emails: %s",
+	},
+}
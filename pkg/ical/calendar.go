@@ -0,0 +1,110 @@
+// Package ical implements methods to render wishlists as iCalendar feeds.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
+)
+
+// maxLineLength is the maximum length of a content line, in octets, as defined by
+// RFC 5545.
+const maxLineLength = 75
+
+// reminder is the delay before the event when calendars should remind the user to
+// check the list.
+const reminder = "-P7D"
+
+// WriteWishLists writes an iCalendar document with an event for each given wishlist.
+//
+// Wishlists without event date are ignored. The listURL function is used to build the
+// share link of each wishlist, which is added to the event description. The texts are
+// written with the given printer, and the siteName is used as the name of the calendar.
+func WriteWishLists(
+	w io.Writer,
+	lists []wishlister.WishList,
+	printer i18n.Printer,
+	siteName string,
+	listURL func(listID string) string,
+) error {
+	bw := bufio.NewWriter(w)
+	now := time.Now().UTC().Format("20060102T150405Z")
+
+	writeLine(bw, "BEGIN:VCALENDAR")
+	writeLine(bw, "VERSION:2.0")
+	writeLine(bw, "PRODID:-//erdnaxeli//wishlister//"+strings.ToUpper(printer.Lang()))
+	writeLine(bw, "CALSCALE:GREGORIAN")
+	writeLine(bw, "METHOD:PUBLISH")
+	writeLine(bw, "X-WR-CALNAME:"+escape(siteName))
+
+	for _, list := range lists {
+		if list.EventDate.IsZero() {
+			continue
+		}
+
+		url := listURL(list.ID)
+		summary := list.Name
+		if list.Username != "" {
+			summary = printer.T("calendar.summary", list.Name, list.Username)
+		}
+
+		writeLine(bw, "BEGIN:VEVENT")
+		writeLine(bw, fmt.Sprintf("UID:%s@wishlister", list.ID))
+		writeLine(bw, "DTSTAMP:"+now)
+		writeLine(bw, "DTSTART;VALUE=DATE:"+list.EventDate.Format("20060102"))
+		writeLine(bw, "DTEND;VALUE=DATE:"+list.EventDate.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(bw, "SUMMARY:"+escape(summary))
+		writeLine(bw, "DESCRIPTION:"+escape(printer.T("calendar.description", url)))
+		writeLine(bw, "URL:"+url)
+		writeLine(bw, "BEGIN:VALARM")
+		writeLine(bw, "ACTION:DISPLAY")
+		writeLine(bw, "DESCRIPTION:"+escape(printer.T("calendar.reminder", url)))
+		writeLine(bw, "TRIGGER:"+reminder)
+		writeLine(bw, "END:VALARM")
+		writeLine(bw, "END:VEVENT")
+	}
+
+	writeLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// writeLine writes a content line, folded as required by RFC 5545.
+//
+// Errors are not returned, as they are kept by the bufio.Writer and returned by Flush.
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		// do not split a multi-bytes character
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		_, _ = w.WriteString(line[:cut])
+		_, _ = w.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space counts in the line length
+		limit = maxLineLength - 1
+	}
+
+	_, _ = w.WriteString(line)
+	_, _ = w.WriteString("\r\n")
+}
+
+// escape escapes a text value as required by RFC 5545.
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
@@ -0,0 +1,12 @@
+-- +migrate Up
+create table wishlist_changes (
+    id TEXT primary key,
+    wishlist_id TEXT not null references wishlists (id),
+    kind TEXT not null,
+    element_name TEXT not null,
+    element_description TEXT,
+    element_url TEXT,
+    created_at INTEGER not null
+) strict;
+
+create index wishlist_changes_wishlist_id on wishlist_changes (wishlist_id, created_at);
@@ -0,0 +1,2 @@
+-- +migrate Up
+alter table users add column lang TEXT not null default '';
@@ -0,0 +1,15 @@
+-- +migrate Up
+create table email_outbox (
+    id TEXT primary key,
+    kind TEXT not null,
+    recipient TEXT not null,
+    lang TEXT not null,
+    payload TEXT not null,
+    status TEXT not null,
+    attempts INTEGER not null,
+    next_attempt_at INTEGER not null,
+    last_error TEXT,
+    created_at INTEGER not null
+) strict;
+
+create index email_outbox_status on email_outbox (status, next_attempt_at);
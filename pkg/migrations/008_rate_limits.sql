@@ -0,0 +1,8 @@
+-- +migrate Up
+create table rate_limits (
+    key TEXT primary key,
+    window_start INTEGER not null,
+    hits INTEGER not null
+) strict;
+
+create index rate_limits_window_start on rate_limits (window_start);
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table user_sessions add column created_at INTEGER not null default 0;
+alter table user_sessions add column last_used_at INTEGER not null default 0;
+
+-- the existing sessions start their lifetime now, instead of expiring at once
+update user_sessions
+set
+    created_at = cast(strftime('%s', 'now') as INTEGER),
+    last_used_at = cast(strftime('%s', 'now') as INTEGER);
@@ -0,0 +1,2 @@
+-- +migrate Up
+alter table user_sessions add column magic_link_binding TEXT not null default '';
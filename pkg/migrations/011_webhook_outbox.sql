@@ -0,0 +1,13 @@
+-- +migrate Up
+create table webhook_outbox (
+    id TEXT primary key,
+    webhook_id TEXT not null,
+    event TEXT not null,
+    payload TEXT not null,
+    attempts INTEGER not null,
+    next_attempt_at INTEGER not null,
+    created_at INTEGER not null
+) strict;
+
+create index webhook_outbox_next_attempt_at on webhook_outbox (next_attempt_at);
+create index webhook_outbox_webhook_id on webhook_outbox (webhook_id);
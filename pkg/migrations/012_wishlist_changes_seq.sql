@@ -0,0 +1,8 @@
+-- +migrate Up
+-- The changes written by the same update share their created_at, so they are also
+-- ordered by their insertion sequence.
+alter table wishlist_changes add column seq INTEGER not null default 0;
+update wishlist_changes set seq = rowid;
+
+drop index wishlist_changes_wishlist_id;
+create index wishlist_changes_wishlist_id on wishlist_changes (wishlist_id, created_at, seq);
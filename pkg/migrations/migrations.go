@@ -0,0 +1,85 @@
+// Package migrations holds the migrations of the database schema of each engine.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"io/fs"
+	"strconv"
+
+	"github.com/erdnaxeli/migrator"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+//go:embed *.sql postgres/*.sql
+var migrations embed.FS
+
+// File is a migration embedded in the binary.
+type File struct {
+	Version int
+	Name    string
+	// Filename is relative to the migrations directory of the engine.
+	Filename string
+}
+
+// dir returns the directory of the migrations of the given engine.
+func dir(engine wishlister.Engine) string {
+	if engine == wishlister.EnginePostgres {
+		return "postgres"
+	}
+
+	return "."
+}
+
+// New returns the migrator applying the migrations of the given engine.
+func New(db *sql.DB, engine wishlister.Engine) (migrator.Migrator, error) {
+	subFS, err := fs.Sub(migrations, dir(engine))
+	if err != nil {
+		return nil, err
+	}
+
+	if engine == wishlister.EnginePostgres {
+		return newPostgresMigrator(db, subFS)
+	}
+
+	return migrator.New(db, subFS)
+}
+
+// Files returns the migrations of the given engine, ordered by version.
+func Files(engine wishlister.Engine) ([]File, error) {
+	subFS, err := fs.Sub(migrations, dir(engine))
+	if err != nil {
+		return nil, err
+	}
+
+	return listFiles(subFS)
+}
+
+func listFiles(subFS fs.FS) ([]File, error) {
+	matches, err := fs.Glob(subFS, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(matches))
+	for _, filename := range matches {
+		submatches := migrator.FilenameRgx.FindStringSubmatch(filename)
+		if submatches == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(submatches[1])
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, File{
+			Version:  version,
+			Name:     submatches[2],
+			Filename: filename,
+		})
+	}
+
+	return files, nil
+}
@@ -0,0 +1,33 @@
+-- +migrate Up
+create table groups (
+    id TEXT primary key,
+    name TEXT not null
+);
+
+create table users (
+    id TEXT primary key,
+    name TEXT not null,
+    email TEXT unique not null
+);
+
+create table wishlists (
+    id TEXT primary key,
+    admin_id TEXT not null,
+    user_id TEXT not null references users (id),
+    name TEXT not null,
+    group_id TEXT references groups (id)
+);
+
+create table wishlist_elements (
+    id TEXT primary key,
+    wishlist_id TEXT not null references wishlists (id),
+    name TEXT not null,
+    description TEXT,
+    url TEXT
+);
+
+create table user_sessions (
+    id TEXT primary key,
+    user_id TEXT not null references users (id),
+    magic_link_token TEXT unique
+);
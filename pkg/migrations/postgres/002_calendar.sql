@@ -0,0 +1,6 @@
+-- +migrate Up
+alter table wishlists add column event_date TEXT;
+
+alter table users add column calendar_token TEXT;
+
+create unique index users_calendar_token on users (calendar_token);
@@ -0,0 +1,23 @@
+-- +migrate Up
+create table webhooks (
+    id TEXT primary key,
+    wishlist_id TEXT not null references wishlists (id),
+    url TEXT not null,
+    secret TEXT not null,
+    created_at BIGINT not null
+);
+
+create index webhooks_wishlist_id on webhooks (wishlist_id);
+
+create table webhook_deliveries (
+    id TEXT primary key,
+    webhook_id TEXT not null references webhooks (id),
+    event TEXT not null,
+    payload TEXT not null,
+    attempt BIGINT not null,
+    status_code BIGINT,
+    error TEXT,
+    created_at BIGINT not null
+);
+
+create index webhook_deliveries_webhook_id on webhook_deliveries (webhook_id, created_at);
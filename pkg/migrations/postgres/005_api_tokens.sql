@@ -0,0 +1,11 @@
+-- +migrate Up
+create table api_tokens (
+    id TEXT primary key,
+    user_id TEXT not null references users (id),
+    name TEXT not null,
+    token_hash TEXT unique not null,
+    read_only BIGINT not null,
+    created_at BIGINT not null
+);
+
+create index api_tokens_user_id on api_tokens (user_id);
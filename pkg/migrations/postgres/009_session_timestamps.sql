@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table user_sessions add column created_at BIGINT not null default 0;
+alter table user_sessions add column last_used_at BIGINT not null default 0;
+
+-- the existing sessions start their lifetime now, instead of expiring at once
+update user_sessions
+set
+    created_at = extract(epoch from now())::BIGINT,
+    last_used_at = extract(epoch from now())::BIGINT;
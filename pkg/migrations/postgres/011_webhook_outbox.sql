@@ -0,0 +1,13 @@
+-- +migrate Up
+create table webhook_outbox (
+    id TEXT primary key,
+    webhook_id TEXT not null references webhooks (id),
+    event TEXT not null,
+    payload TEXT not null,
+    attempts BIGINT not null,
+    next_attempt_at BIGINT not null,
+    created_at BIGINT not null
+);
+
+create index webhook_outbox_next_attempt_at on webhook_outbox (next_attempt_at);
+create index webhook_outbox_webhook_id on webhook_outbox (webhook_id);
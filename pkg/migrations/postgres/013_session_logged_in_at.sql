@@ -0,0 +1,5 @@
+-- +migrate Up
+-- The lifetime of a session starts when its magic link is used, not when it is sent.
+alter table user_sessions add column logged_in_at BIGINT not null default 0;
+
+update user_sessions set logged_in_at = created_at;
@@ -0,0 +1,145 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"strings"
+
+	"github.com/erdnaxeli/migrator"
+)
+
+// postgresMigrationsLock is the key of the advisory lock taken while applying the
+// migrations, so replicas starting together do not apply them twice.
+const postgresMigrationsLock = 7_357_001
+
+// postgresMigrator applies migrations on a PostgreSQL database.
+//
+// It follows the conventions of the migrator package, whose implementation only
+// supports SQLite: the same filenames, the same "-- +migrate Up" header and the same
+// schema_migrations table.
+type postgresMigrator struct {
+	db    *sql.DB
+	files fs.FS
+}
+
+var _ migrator.Migrator = postgresMigrator{}
+
+func newPostgresMigrator(db *sql.DB, files fs.FS) (postgresMigrator, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return postgresMigrator{}, fmt.Errorf("error while creating migrations table: %w", err)
+	}
+
+	return postgresMigrator{db: db, files: files}, nil
+}
+
+func (m postgresMigrator) Version() (int, error) {
+	var version sql.NullInt64
+	err := m.db.QueryRow("SELECT max(version) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(version.Int64), nil
+}
+
+// Migrate applies the pending migrations.
+//
+// All of them are applied in a single transaction, so a failed migration leaves the
+// database untouched.
+func (m postgresMigrator) Migrate() (err error) {
+	ctx := context.Background()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", postgresMigrationsLock)
+	if err != nil {
+		return err
+	}
+
+	var current sql.NullInt64
+	err = tx.QueryRowContext(ctx, "SELECT max(version) FROM schema_migrations").Scan(&current)
+	if err != nil {
+		return err
+	}
+
+	files, err := listFiles(m.files)
+	if err != nil {
+		return err
+	}
+
+	applied := 0
+	for _, file := range files {
+		if int64(file.Version) <= current.Int64 {
+			continue
+		}
+
+		err = m.applyMigration(ctx, tx, file)
+		if err != nil {
+			return err
+		}
+
+		applied++
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if applied == 0 {
+		log.Print("Database is already up to date.")
+	}
+
+	return nil
+}
+
+func (m postgresMigrator) applyMigration(
+	ctx context.Context,
+	tx *sql.Tx,
+	file File,
+) error {
+	log.Printf("Applying migration %d %s", file.Version, file.Name)
+
+	content, err := fs.ReadFile(m.files, file.Filename)
+	if err != nil {
+		return err
+	}
+
+	if len(content) == 0 {
+		return migrator.EmptyMigrationError{Filename: file.Filename}
+	}
+
+	header, _, _ := strings.Cut(string(content), "\n")
+	if header != "-- +migrate Up" {
+		return migrator.InvalidMigrationFileError{Filename: file.Filename}
+	}
+
+	// Without arguments, the statements are sent with the simple protocol, which
+	// accepts several of them at once.
+	_, err = tx.ExecContext(ctx, string(content))
+	if err != nil {
+		return fmt.Errorf("error in migration %s: %w", file.Filename, err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO schema_migrations (version) VALUES ($1)",
+		file.Version,
+	)
+	return err
+}
@@ -0,0 +1,143 @@
+// Package pdf implements methods to render wishlists as printable PDF documents.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+	qrcode "github.com/skip2/go-qrcode"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
+)
+
+const (
+	// size of the QR codes, in mm
+	qrCodeSize = 25
+	// size of the generated QR codes images, in pixels
+	qrCodePixels = 256
+	// space between two elements, in mm
+	elementSpacing = 6
+)
+
+// WriteWishList writes a PDF document representing the given wishlist to w.
+//
+// The document contains the name of the list, the name of its owner, and all its
+// elements with their description. A QR code is added for each element with an URL,
+// so the link can be opened from the paper version.
+//
+// The texts are written with the given printer, and the siteName is written as the
+// creator of the document.
+func WriteWishList(
+	w io.Writer,
+	list wishlister.WishList,
+	printer i18n.Printer,
+	siteName string,
+) error {
+	doc := fpdf.New("P", "mm", "A4", "")
+	// Core fonts only support cp1252, which covers french characters.
+	tr := doc.UnicodeTranslatorFromDescriptor("")
+
+	doc.SetTitle(list.Name, true)
+	doc.SetAuthor(list.Username, true)
+	doc.SetCreator(siteName, true)
+	doc.SetFooterFunc(func() {
+		doc.SetY(-15)
+		doc.SetFont("Helvetica", "I", 8)
+		doc.SetTextColor(128, 128, 128)
+		doc.CellFormat(0, 10, fmt.Sprintf("%d", doc.PageNo()), "", 0, "C", false, 0, "")
+	})
+	doc.AddPage()
+
+	doc.SetFont("Helvetica", "B", 20)
+	doc.MultiCell(0, 10, tr(list.Name), "", "L", false)
+	doc.SetFont("Helvetica", "", 12)
+	doc.SetTextColor(100, 100, 100)
+	doc.MultiCell(0, 7, tr(printer.T("print.owner", list.Username)), "", "L", false)
+	doc.Ln(elementSpacing)
+
+	if len(list.Elements) == 0 {
+		doc.SetFont("Helvetica", "I", 12)
+		doc.MultiCell(0, 7, tr(printer.T("print.empty")), "", "L", false)
+	}
+
+	for idx, element := range list.Elements {
+		err := writeElement(doc, tr, idx, element)
+		if err != nil {
+			return err
+		}
+	}
+
+	return doc.Output(w)
+}
+
+func writeElement(
+	doc *fpdf.Fpdf,
+	tr func(string) string,
+	idx int,
+	element wishlister.WishListElement,
+) error {
+	pageWidth, pageHeight := doc.GetPageSize()
+	left, _, right, bottom := doc.GetMargins()
+	textWidth := pageWidth - left - right
+
+	if element.URL != "" {
+		textWidth -= qrCodeSize + elementSpacing
+	}
+
+	// We do not want an element to be split between two pages, and we cannot rely on
+	// the auto page break as the QR code is not part of the text flow.
+	doc.SetFont("Helvetica", "B", 14)
+	height := float64(7 * len(doc.SplitLines([]byte(tr(element.Name)), textWidth)))
+	if element.Description != "" {
+		doc.SetFont("Helvetica", "", 11)
+		height += float64(6 * len(doc.SplitLines([]byte(tr(element.Description)), textWidth)))
+	}
+	if element.URL != "" {
+		height = max(height, qrCodeSize)
+	}
+
+	if doc.GetY()+height > pageHeight-bottom-15 {
+		doc.AddPage()
+	}
+
+	top := doc.GetY()
+
+	doc.SetTextColor(0, 0, 0)
+	doc.SetFont("Helvetica", "B", 14)
+	doc.MultiCell(textWidth, 7, tr(element.Name), "", "L", false)
+
+	if element.Description != "" {
+		doc.SetFont("Helvetica", "", 11)
+		doc.SetTextColor(80, 80, 80)
+		doc.MultiCell(textWidth, 6, tr(element.Description), "", "L", false)
+	}
+
+	if element.URL != "" {
+		png, err := qrcode.Encode(element.URL, qrcode.Medium, qrCodePixels)
+		if err != nil {
+			return fmt.Errorf("error while generating QR code: %w", err)
+		}
+
+		name := fmt.Sprintf("qrcode-%d", idx)
+		options := fpdf.ImageOptions{ImageType: "PNG"}
+		doc.RegisterImageOptionsReader(name, options, bytes.NewReader(png))
+		doc.ImageOptions(
+			name,
+			pageWidth-right-qrCodeSize,
+			top,
+			qrCodeSize,
+			qrCodeSize,
+			false,
+			options,
+			0,
+			element.URL,
+		)
+	}
+
+	doc.SetY(max(doc.GetY(), top+height) + elementSpacing)
+
+	return doc.Error()
+}
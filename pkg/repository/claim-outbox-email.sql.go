@@ -0,0 +1,33 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: claim-outbox-email.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const claimOutboxEmail = `-- name: ClaimOutboxEmail :execrows
+update email_outbox
+set next_attempt_at = ?1
+where id = ?2
+    and status = 'pending'
+    -- the email was not claimed by another worker in the meantime
+    and next_attempt_at = ?3
+`
+
+type ClaimOutboxEmailParams struct {
+	ClaimedUntil  int64
+	ID            string
+	NextAttemptAt int64
+}
+
+func (q *Queries) ClaimOutboxEmail(ctx context.Context, arg ClaimOutboxEmailParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, claimOutboxEmail, arg.ClaimedUntil, arg.ID, arg.NextAttemptAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
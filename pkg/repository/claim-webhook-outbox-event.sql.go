@@ -0,0 +1,32 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: claim-webhook-outbox-event.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const claimWebhookOutboxEvent = `-- name: ClaimWebhookOutboxEvent :execrows
+update webhook_outbox
+set next_attempt_at = ?1
+where id = ?2
+    -- the event was not claimed by another worker in the meantime
+    and next_attempt_at = ?3
+`
+
+type ClaimWebhookOutboxEventParams struct {
+	ClaimedUntil  int64
+	ID            string
+	NextAttemptAt int64
+}
+
+func (q *Queries) ClaimWebhookOutboxEvent(ctx context.Context, arg ClaimWebhookOutboxEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, claimWebhookOutboxEvent, arg.ClaimedUntil, arg.ID, arg.NextAttemptAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -11,17 +11,29 @@ import (
 )
 
 const createUserSession = `-- name: CreateUserSession :exec
-insert into user_sessions (id, user_id, magic_link_token)
-values (?, ?, ?)
+insert into user_sessions (
+    id, user_id, magic_link_token, magic_link_binding, created_at, last_used_at
+)
+values (?, ?, ?, ?, ?, ?)
 `
 
 type CreateUserSessionParams struct {
-	ID             string
-	UserID         string
-	MagicLinkToken sql.NullString
+	ID               string
+	UserID           string
+	MagicLinkToken   sql.NullString
+	MagicLinkBinding string
+	CreatedAt        int64
+	LastUsedAt       int64
 }
 
 func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionParams) error {
-	_, err := q.db.ExecContext(ctx, createUserSession, arg.ID, arg.UserID, arg.MagicLinkToken)
+	_, err := q.db.ExecContext(ctx, createUserSession,
+		arg.ID,
+		arg.UserID,
+		arg.MagicLinkToken,
+		arg.MagicLinkBinding,
+		arg.CreatedAt,
+		arg.LastUsedAt,
+	)
 	return err
 }
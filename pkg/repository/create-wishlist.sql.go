@@ -12,19 +12,20 @@ import (
 
 const createWishList = `-- name: CreateWishList :exec
 insert into wishlists (
-    id, admin_id, name, group_id, user_id
+    id, admin_id, name, group_id, user_id, event_date
 )
 values (
-    ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?
 )
 `
 
 type CreateWishListParams struct {
-	ID      string
-	AdminID string
-	Name    string
-	GroupID sql.NullString
-	UserID  string
+	ID        string
+	AdminID   string
+	Name      string
+	GroupID   sql.NullString
+	UserID    string
+	EventDate sql.NullString
 }
 
 func (q *Queries) CreateWishList(ctx context.Context, arg CreateWishListParams) error {
@@ -34,6 +35,7 @@ func (q *Queries) CreateWishList(ctx context.Context, arg CreateWishListParams)
 		arg.Name,
 		arg.GroupID,
 		arg.UserID,
+		arg.EventDate,
 	)
 	return err
 }
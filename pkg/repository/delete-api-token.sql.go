@@ -0,0 +1,28 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-api-token.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteAPIToken = `-- name: DeleteAPIToken :execrows
+delete from api_tokens
+where id = ? and user_id = ?
+`
+
+type DeleteAPITokenParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) DeleteAPIToken(ctx context.Context, arg DeleteAPITokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAPIToken, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-expired-rate-limits.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteExpiredRateLimits = `-- name: DeleteExpiredRateLimits :exec
+delete from rate_limits
+where window_start < ?
+`
+
+func (q *Queries) DeleteExpiredRateLimits(ctx context.Context, windowStart int64) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredRateLimits, windowStart)
+	return err
+}
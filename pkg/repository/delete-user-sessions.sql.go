@@ -0,0 +1,23 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-user-sessions.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteUserSessions = `-- name: DeleteUserSessions :execrows
+delete from user_sessions
+where user_id = ?
+`
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, userID string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserSessions, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-webhook-outbox-events.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteWebhookOutboxEvents = `-- name: DeleteWebhookOutboxEvents :exec
+delete from webhook_outbox
+where webhook_id = ?
+`
+
+func (q *Queries) DeleteWebhookOutboxEvents(ctx context.Context, webhookID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookOutboxEvents, webhookID)
+	return err
+}
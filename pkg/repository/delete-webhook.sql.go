@@ -0,0 +1,28 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-webhook.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteWebhook = `-- name: DeleteWebhook :execrows
+delete from webhooks
+where id = ? and wishlist_id = ?
+`
+
+type DeleteWebhookParams struct {
+	ID         string
+	WishlistID string
+}
+
+func (q *Queries) DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWebhook, arg.ID, arg.WishlistID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
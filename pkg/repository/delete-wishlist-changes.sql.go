@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-wishlist-changes.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteWishListChanges = `-- name: DeleteWishListChanges :exec
+delete from wishlist_changes
+where wishlist_id = ?
+`
+
+func (q *Queries) DeleteWishListChanges(ctx context.Context, wishlistID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWishListChanges, wishlistID)
+	return err
+}
@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-wishlist-webhook-deliveries.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteWishListWebhookDeliveries = `-- name: DeleteWishListWebhookDeliveries :exec
+delete from webhook_deliveries
+where webhook_id in (
+    select id from webhooks where wishlist_id = ?
+)
+`
+
+func (q *Queries) DeleteWishListWebhookDeliveries(ctx context.Context, wishlistID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWishListWebhookDeliveries, wishlistID)
+	return err
+}
@@ -0,0 +1,23 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-wishlist.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const deleteWishList = `-- name: DeleteWishList :execrows
+delete from wishlists
+where id = ?
+`
+
+func (q *Queries) DeleteWishList(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWishList, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
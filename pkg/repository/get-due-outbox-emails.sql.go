@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-due-outbox-emails.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const getDueOutboxEmails = `-- name: GetDueOutboxEmails :many
+select id, kind, recipient, lang, payload, attempts, next_attempt_at
+from email_outbox
+where status = 'pending'
+    and next_attempt_at <= ?
+order by next_attempt_at
+limit ?
+`
+
+type GetDueOutboxEmailsParams struct {
+	NextAttemptAt int64
+	Limit         int64
+}
+
+type GetDueOutboxEmailsRow struct {
+	ID            string
+	Kind          string
+	Recipient     string
+	Lang          string
+	Payload       string
+	Attempts      int64
+	NextAttemptAt int64
+}
+
+func (q *Queries) GetDueOutboxEmails(ctx context.Context, arg GetDueOutboxEmailsParams) ([]GetDueOutboxEmailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDueOutboxEmails, arg.NextAttemptAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDueOutboxEmailsRow
+	for rows.Next() {
+		var i GetDueOutboxEmailsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Recipient,
+			&i.Lang,
+			&i.Payload,
+			&i.Attempts,
+			&i.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
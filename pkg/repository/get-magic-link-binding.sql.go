@@ -0,0 +1,33 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-magic-link-binding.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getMagicLinkBinding = `-- name: GetMagicLinkBinding :one
+select magic_link_binding
+from user_sessions
+where magic_link_token = ?1
+    -- safety check to ensure the given token is not null
+    and magic_link_token is not null
+    -- the magic link has not expired
+    and created_at >= ?2
+`
+
+type GetMagicLinkBindingParams struct {
+	MagicLinkToken sql.NullString
+	CreatedAfter   int64
+}
+
+func (q *Queries) GetMagicLinkBinding(ctx context.Context, arg GetMagicLinkBindingParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getMagicLinkBinding, arg.MagicLinkToken, arg.CreatedAfter)
+	var magic_link_binding string
+	err := row.Scan(&magic_link_binding)
+	return magic_link_binding, err
+}
@@ -10,21 +10,42 @@ import (
 )
 
 const getOrCreateUser = `-- name: GetOrCreateUser :one
-insert into users (id, name, email)
-values (?, ?, ?)
-on conflict (email) do update set name = excluded.name
-returning id, name, email
+insert into users (id, name, email, lang)
+values (?, ?, ?, ?)
+on conflict (email) do update set
+    name = excluded.name,
+    -- an empty language keeps the known one
+    lang = coalesce(nullif(excluded.lang, ''), users.lang)
+returning id, name, email, lang
 `
 
 type GetOrCreateUserParams struct {
 	ID    string
 	Name  string
 	Email string
+	Lang  string
 }
 
-func (q *Queries) GetOrCreateUser(ctx context.Context, arg GetOrCreateUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, getOrCreateUser, arg.ID, arg.Name, arg.Email)
-	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+type GetOrCreateUserRow struct {
+	ID    string
+	Name  string
+	Email string
+	Lang  string
+}
+
+func (q *Queries) GetOrCreateUser(ctx context.Context, arg GetOrCreateUserParams) (GetOrCreateUserRow, error) {
+	row := q.db.QueryRowContext(ctx, getOrCreateUser,
+		arg.ID,
+		arg.Name,
+		arg.Email,
+		arg.Lang,
+	)
+	var i GetOrCreateUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Lang,
+	)
 	return i, err
 }
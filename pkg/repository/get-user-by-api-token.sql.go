@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-user-by-api-token.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const getUserByAPIToken = `-- name: GetUserByAPIToken :one
+select
+    users.id,
+    users.name as username,
+    users.email as user_email,
+    api_tokens.read_only
+from api_tokens
+join users on users.id = api_tokens.user_id
+where api_tokens.token_hash = ?
+`
+
+type GetUserByAPITokenRow struct {
+	ID        string
+	Username  string
+	UserEmail string
+	ReadOnly  int64
+}
+
+func (q *Queries) GetUserByAPIToken(ctx context.Context, tokenHash string) (GetUserByAPITokenRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByAPIToken, tokenHash)
+	var i GetUserByAPITokenRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.UserEmail,
+		&i.ReadOnly,
+	)
+	return i, err
+}
@@ -0,0 +1,24 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-user-calendar-token.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getUserCalendarToken = `-- name: GetUserCalendarToken :one
+select calendar_token
+from users
+where id = ?
+`
+
+func (q *Queries) GetUserCalendarToken(ctx context.Context, id string) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getUserCalendarToken, id)
+	var calendar_token sql.NullString
+	err := row.Scan(&calendar_token)
+	return calendar_token, err
+}
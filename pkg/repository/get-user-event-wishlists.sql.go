@@ -0,0 +1,59 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-user-event-wishlists.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getUserEventWishLists = `-- name: GetUserEventWishLists :many
+select
+    wishlists.id,
+    wishlists.name,
+    event_date,
+    users.name as username
+from wishlists
+join users on wishlists.user_id = users.id
+where user_id = ?
+    and event_date is not null
+order by event_date
+`
+
+type GetUserEventWishListsRow struct {
+	ID        string
+	Name      string
+	EventDate sql.NullString
+	Username  string
+}
+
+func (q *Queries) GetUserEventWishLists(ctx context.Context, userID string) ([]GetUserEventWishListsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserEventWishLists, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserEventWishListsRow
+	for rows.Next() {
+		var i GetUserEventWishListsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.EventDate,
+			&i.Username,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -12,21 +12,41 @@ import (
 
 const getUserSessionByMagicLink = `-- name: GetUserSessionByMagicLink :one
 update user_sessions
-set magic_link_token = null
-where magic_link_token = ?
+set
+    magic_link_token = null,
+    logged_in_at = ?1,
+    last_used_at = ?1
+where magic_link_token = ?2
     -- safety check to ensure the given token is not null
     and magic_link_token is not null
-returning id, user_id
+    -- the magic link is used in the browser it was asked from
+    and magic_link_binding = ?3
+    -- the magic link has not expired
+    and created_at >= ?4
+returning id, user_id, logged_in_at
 `
 
+type GetUserSessionByMagicLinkParams struct {
+	LoggedInAt       int64
+	MagicLinkToken   sql.NullString
+	MagicLinkBinding string
+	CreatedAfter     int64
+}
+
 type GetUserSessionByMagicLinkRow struct {
-	ID     string
-	UserID string
+	ID         string
+	UserID     string
+	LoggedInAt int64
 }
 
-func (q *Queries) GetUserSessionByMagicLink(ctx context.Context, magicLinkToken sql.NullString) (GetUserSessionByMagicLinkRow, error) {
-	row := q.db.QueryRowContext(ctx, getUserSessionByMagicLink, magicLinkToken)
+func (q *Queries) GetUserSessionByMagicLink(ctx context.Context, arg GetUserSessionByMagicLinkParams) (GetUserSessionByMagicLinkRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserSessionByMagicLink,
+		arg.LoggedInAt,
+		arg.MagicLinkToken,
+		arg.MagicLinkBinding,
+		arg.CreatedAfter,
+	)
 	var i GetUserSessionByMagicLinkRow
-	err := row.Scan(&i.ID, &i.UserID)
+	err := row.Scan(&i.ID, &i.UserID, &i.LoggedInAt)
 	return i, err
 }
@@ -14,17 +14,21 @@ select
     user_sessions.id,
     user_id,
     users.name as username,
-    users.email as user_email
+    users.email as user_email,
+    user_sessions.logged_in_at,
+    user_sessions.last_used_at
 from user_sessions
 join users on users.id = user_sessions.user_id
 where user_sessions.id = ?
 `
 
 type GetUserSessionRow struct {
-	ID        string
-	UserID    string
-	Username  string
-	UserEmail string
+	ID         string
+	UserID     string
+	Username   string
+	UserEmail  string
+	LoggedInAt int64
+	LastUsedAt int64
 }
 
 func (q *Queries) GetUserSession(ctx context.Context, id string) (GetUserSessionRow, error) {
@@ -35,6 +39,8 @@ func (q *Queries) GetUserSession(ctx context.Context, id string) (GetUserSession
 		&i.UserID,
 		&i.Username,
 		&i.UserEmail,
+		&i.LoggedInAt,
+		&i.LastUsedAt,
 	)
 	return i, err
 }
@@ -7,18 +7,20 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 )
 
 const getUserWishLists = `-- name: GetUserWishLists :many
-select id, admin_id, name
+select id, admin_id, name, event_date
 from wishlists
 where user_id = ?
 `
 
 type GetUserWishListsRow struct {
-	ID      string
-	AdminID string
-	Name    string
+	ID        string
+	AdminID   string
+	Name      string
+	EventDate sql.NullString
 }
 
 func (q *Queries) GetUserWishLists(ctx context.Context, userID string) ([]GetUserWishListsRow, error) {
@@ -30,7 +32,12 @@ func (q *Queries) GetUserWishLists(ctx context.Context, userID string) ([]GetUse
 	var items []GetUserWishListsRow
 	for rows.Next() {
 		var i GetUserWishListsRow
-		if err := rows.Scan(&i.ID, &i.AdminID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdminID,
+			&i.Name,
+			&i.EventDate,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
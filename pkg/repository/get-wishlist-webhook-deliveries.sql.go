@@ -0,0 +1,76 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-wishlist-webhook-deliveries.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getWishListWebhookDeliveries = `-- name: GetWishListWebhookDeliveries :many
+select
+    webhook_deliveries.id,
+    webhook_id,
+    webhooks.url,
+    event,
+    attempt,
+    status_code,
+    error,
+    webhook_deliveries.created_at
+from webhook_deliveries
+join webhooks on webhooks.id = webhook_deliveries.webhook_id
+where webhooks.wishlist_id = ?
+order by webhook_deliveries.created_at desc
+limit ?
+`
+
+type GetWishListWebhookDeliveriesParams struct {
+	WishlistID string
+	Limit      int64
+}
+
+type GetWishListWebhookDeliveriesRow struct {
+	ID         string
+	WebhookID  string
+	Url        string
+	Event      string
+	Attempt    int64
+	StatusCode sql.NullInt64
+	Error      sql.NullString
+	CreatedAt  int64
+}
+
+func (q *Queries) GetWishListWebhookDeliveries(ctx context.Context, arg GetWishListWebhookDeliveriesParams) ([]GetWishListWebhookDeliveriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWishListWebhookDeliveries, arg.WishlistID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWishListWebhookDeliveriesRow
+	for rows.Next() {
+		var i GetWishListWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.Url,
+			&i.Event,
+			&i.Attempt,
+			&i.StatusCode,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
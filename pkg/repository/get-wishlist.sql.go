@@ -16,6 +16,7 @@ select
     admin_id,
     group_id,
     wishlists.name,
+    event_date,
     users.name as username
 from wishlists
 join users on wishlists.user_id = users.id
@@ -23,11 +24,12 @@ where wishlists.id = ?
 `
 
 type GetWishListRow struct {
-	ID       string
-	AdminID  string
-	GroupID  sql.NullString
-	Name     string
-	Username string
+	ID        string
+	AdminID   string
+	GroupID   sql.NullString
+	Name      string
+	EventDate sql.NullString
+	Username  string
 }
 
 func (q *Queries) GetWishList(ctx context.Context, id string) (GetWishListRow, error) {
@@ -38,6 +40,7 @@ func (q *Queries) GetWishList(ctx context.Context, id string) (GetWishListRow, e
 		&i.AdminID,
 		&i.GroupID,
 		&i.Name,
+		&i.EventDate,
 		&i.Username,
 	)
 	return i, err
@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: hit-rate-limit.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const hitRateLimit = `-- name: HitRateLimit :one
+insert into rate_limits (key, window_start, hits)
+values (?, ?, 1)
+on conflict (key) do update set
+    -- the hits of a previous window are forgotten
+    hits = case
+        when rate_limits.window_start = excluded.window_start then rate_limits.hits + 1
+        else 1
+    end,
+    window_start = excluded.window_start
+returning hits
+`
+
+type HitRateLimitParams struct {
+	Key         string
+	WindowStart int64
+}
+
+func (q *Queries) HitRateLimit(ctx context.Context, arg HitRateLimitParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, hitRateLimit, arg.Key, arg.WindowStart)
+	var hits int64
+	err := row.Scan(&hits)
+	return hits, err
+}
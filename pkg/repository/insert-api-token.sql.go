@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: insert-api-token.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const insertAPIToken = `-- name: InsertAPIToken :exec
+insert into api_tokens (
+    id, user_id, name, token_hash, read_only, created_at
+)
+values (
+    ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertAPITokenParams struct {
+	ID        string
+	UserID    string
+	Name      string
+	TokenHash string
+	ReadOnly  int64
+	CreatedAt int64
+}
+
+func (q *Queries) InsertAPIToken(ctx context.Context, arg InsertAPITokenParams) error {
+	_, err := q.db.ExecContext(ctx, insertAPIToken,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.TokenHash,
+		arg.ReadOnly,
+		arg.CreatedAt,
+	)
+	return err
+}
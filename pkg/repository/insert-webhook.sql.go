@@ -0,0 +1,38 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: insert-webhook.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const insertWebhook = `-- name: InsertWebhook :exec
+insert into webhooks (
+    id, wishlist_id, url, secret, created_at
+)
+values (
+    ?, ?, ?, ?, ?
+)
+`
+
+type InsertWebhookParams struct {
+	ID         string
+	WishlistID string
+	Url        string
+	Secret     string
+	CreatedAt  int64
+}
+
+func (q *Queries) InsertWebhook(ctx context.Context, arg InsertWebhookParams) error {
+	_, err := q.db.ExecContext(ctx, insertWebhook,
+		arg.ID,
+		arg.WishlistID,
+		arg.Url,
+		arg.Secret,
+		arg.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,39 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: create-user-session.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createUserSession = `-- name: CreateUserSession :exec
+insert into user_sessions (
+    id, user_id, magic_link_token, magic_link_binding, created_at, last_used_at
+)
+values ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateUserSessionParams struct {
+	ID               string
+	UserID           string
+	MagicLinkToken   sql.NullString
+	MagicLinkBinding string
+	CreatedAt        int64
+	LastUsedAt       int64
+}
+
+func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionParams) error {
+	_, err := q.db.ExecContext(ctx, createUserSession,
+		arg.ID,
+		arg.UserID,
+		arg.MagicLinkToken,
+		arg.MagicLinkBinding,
+		arg.CreatedAt,
+		arg.LastUsedAt,
+	)
+	return err
+}
@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-all-user-sessions.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const deleteAllUserSessions = `-- name: DeleteAllUserSessions :execrows
+delete from user_sessions
+`
+
+func (q *Queries) DeleteAllUserSessions(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAllUserSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,32 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-expired-user-sessions.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const deleteExpiredUserSessions = `-- name: DeleteExpiredUserSessions :execrows
+delete from user_sessions
+where (magic_link_token is null and logged_in_at < $1)
+    or last_used_at < $2
+    -- the magic link was not used in time
+    or (magic_link_token is not null and created_at < $3)
+`
+
+type DeleteExpiredUserSessionsParams struct {
+	LoggedInBefore         int64
+	LastUsedBefore         int64
+	MagicLinkCreatedBefore int64
+}
+
+func (q *Queries) DeleteExpiredUserSessions(ctx context.Context, arg DeleteExpiredUserSessionsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredUserSessions, arg.LoggedInBefore, arg.LastUsedBefore, arg.MagicLinkCreatedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-outbox-email.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const deleteOutboxEmail = `-- name: DeleteOutboxEmail :exec
+delete from email_outbox
+where id = $1
+`
+
+func (q *Queries) DeleteOutboxEmail(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteOutboxEmail, id)
+	return err
+}
@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-webhook-deliveries.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const deleteWebhookDeliveries = `-- name: DeleteWebhookDeliveries :exec
+delete from webhook_deliveries
+where webhook_id = $1
+`
+
+func (q *Queries) DeleteWebhookDeliveries(ctx context.Context, webhookID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookDeliveries, webhookID)
+	return err
+}
@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-webhook-outbox-event.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const deleteWebhookOutboxEvent = `-- name: DeleteWebhookOutboxEvent :exec
+delete from webhook_outbox
+where id = $1
+`
+
+func (q *Queries) DeleteWebhookOutboxEvent(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookOutboxEvent, id)
+	return err
+}
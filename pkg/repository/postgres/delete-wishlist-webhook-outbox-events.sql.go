@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-wishlist-webhook-outbox-events.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const deleteWishListWebhookOutboxEvents = `-- name: DeleteWishListWebhookOutboxEvents :exec
+delete from webhook_outbox
+where webhook_id in (
+    select id from webhooks where wishlist_id = $1
+)
+`
+
+func (q *Queries) DeleteWishListWebhookOutboxEvents(ctx context.Context, wishlistID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWishListWebhookOutboxEvents, wishlistID)
+	return err
+}
@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: delete-wishlist-webhooks.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const deleteWishListWebhooks = `-- name: DeleteWishListWebhooks :exec
+delete from webhooks
+where wishlist_id = $1
+`
+
+func (q *Queries) DeleteWishListWebhooks(ctx context.Context, wishlistID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWishListWebhooks, wishlistID)
+	return err
+}
@@ -0,0 +1,75 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-due-webhook-outbox-events.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const getDueWebhookOutboxEvents = `-- name: GetDueWebhookOutboxEvents :many
+select
+    webhook_outbox.id,
+    webhook_id,
+    webhooks.url,
+    webhooks.secret,
+    event,
+    payload,
+    attempts,
+    next_attempt_at
+from webhook_outbox
+join webhooks on webhooks.id = webhook_outbox.webhook_id
+where next_attempt_at <= $1
+order by next_attempt_at, webhook_outbox.created_at
+limit $2::bigint
+`
+
+type GetDueWebhookOutboxEventsParams struct {
+	NextAttemptAt int64
+	Limit         int64
+}
+
+type GetDueWebhookOutboxEventsRow struct {
+	ID            string
+	WebhookID     string
+	Url           string
+	Secret        string
+	Event         string
+	Payload       string
+	Attempts      int64
+	NextAttemptAt int64
+}
+
+func (q *Queries) GetDueWebhookOutboxEvents(ctx context.Context, arg GetDueWebhookOutboxEventsParams) ([]GetDueWebhookOutboxEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDueWebhookOutboxEvents, arg.NextAttemptAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDueWebhookOutboxEventsRow
+	for rows.Next() {
+		var i GetDueWebhookOutboxEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.Url,
+			&i.Secret,
+			&i.Event,
+			&i.Payload,
+			&i.Attempts,
+			&i.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-failed-outbox-emails.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getFailedOutboxEmails = `-- name: GetFailedOutboxEmails :many
+select id, kind, recipient, attempts, last_error, created_at
+from email_outbox
+where status = 'failed'
+order by created_at
+`
+
+type GetFailedOutboxEmailsRow struct {
+	ID        string
+	Kind      string
+	Recipient string
+	Attempts  int64
+	LastError sql.NullString
+	CreatedAt int64
+}
+
+func (q *Queries) GetFailedOutboxEmails(ctx context.Context) ([]GetFailedOutboxEmailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFailedOutboxEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFailedOutboxEmailsRow
+	for rows.Next() {
+		var i GetFailedOutboxEmailsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Recipient,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
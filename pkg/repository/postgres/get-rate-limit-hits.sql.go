@@ -0,0 +1,28 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-rate-limit-hits.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const getRateLimitHits = `-- name: GetRateLimitHits :one
+select hits
+from rate_limits
+where key = $1 and window_start = $2
+`
+
+type GetRateLimitHitsParams struct {
+	Key         string
+	WindowStart int64
+}
+
+func (q *Queries) GetRateLimitHits(ctx context.Context, arg GetRateLimitHitsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getRateLimitHits, arg.Key, arg.WindowStart)
+	var hits int64
+	err := row.Scan(&hits)
+	return hits, err
+}
@@ -0,0 +1,56 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-user-api-tokens.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const getUserAPITokens = `-- name: GetUserAPITokens :many
+select
+    id,
+    name,
+    read_only,
+    created_at
+from api_tokens
+where user_id = $1
+order by created_at
+`
+
+type GetUserAPITokensRow struct {
+	ID        string
+	Name      string
+	ReadOnly  int64
+	CreatedAt int64
+}
+
+func (q *Queries) GetUserAPITokens(ctx context.Context, userID string) ([]GetUserAPITokensRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserAPITokens, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserAPITokensRow
+	for rows.Next() {
+		var i GetUserAPITokensRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ReadOnly,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,32 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-user-by-calendar-token.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getUserByCalendarToken = `-- name: GetUserByCalendarToken :one
+select id, name, email
+from users
+where calendar_token = $1
+    -- safety check to ensure the given token is not null
+    and calendar_token is not null
+`
+
+type GetUserByCalendarTokenRow struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+func (q *Queries) GetUserByCalendarToken(ctx context.Context, calendarToken sql.NullString) (GetUserByCalendarTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByCalendarToken, calendarToken)
+	var i GetUserByCalendarTokenRow
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
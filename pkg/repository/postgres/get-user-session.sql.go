@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-user-session.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const getUserSession = `-- name: GetUserSession :one
+select
+    user_sessions.id,
+    user_id,
+    users.name as username,
+    users.email as user_email,
+    user_sessions.logged_in_at,
+    user_sessions.last_used_at
+from user_sessions
+join users on users.id = user_sessions.user_id
+where user_sessions.id = $1
+`
+
+type GetUserSessionRow struct {
+	ID         string
+	UserID     string
+	Username   string
+	UserEmail  string
+	LoggedInAt int64
+	LastUsedAt int64
+}
+
+func (q *Queries) GetUserSession(ctx context.Context, id string) (GetUserSessionRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserSession, id)
+	var i GetUserSessionRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Username,
+		&i.UserEmail,
+		&i.LoggedInAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
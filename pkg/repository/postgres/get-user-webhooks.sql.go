@@ -0,0 +1,49 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-user-webhooks.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const getUserWebhooks = `-- name: GetUserWebhooks :many
+select
+    webhooks.id,
+    webhooks.url,
+    webhooks.secret
+from webhooks
+join wishlists on wishlists.id = webhooks.wishlist_id
+where wishlists.user_id = $1
+`
+
+type GetUserWebhooksRow struct {
+	ID     string
+	Url    string
+	Secret string
+}
+
+func (q *Queries) GetUserWebhooks(ctx context.Context, userID string) ([]GetUserWebhooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserWebhooks, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserWebhooksRow
+	for rows.Next() {
+		var i GetUserWebhooksRow
+		if err := rows.Scan(&i.ID, &i.Url, &i.Secret); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
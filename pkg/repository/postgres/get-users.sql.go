@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-users.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const getUsers = `-- name: GetUsers :many
+select
+    users.id,
+    users.name,
+    users.email,
+    count(wishlists.id) as lists_count
+from users
+left join wishlists on wishlists.user_id = users.id
+group by users.id
+order by users.email
+`
+
+type GetUsersRow struct {
+	ID         string
+	Name       string
+	Email      string
+	ListsCount int64
+}
+
+func (q *Queries) GetUsers(ctx context.Context) ([]GetUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUsersRow
+	for rows.Next() {
+		var i GetUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.ListsCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
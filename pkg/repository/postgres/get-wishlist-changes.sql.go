@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-wishlist-changes.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getWishListChanges = `-- name: GetWishListChanges :many
+select
+    id,
+    kind,
+    element_name,
+    element_description,
+    element_url,
+    created_at
+from wishlist_changes
+where wishlist_id = $1
+order by created_at desc, seq desc
+limit $2::bigint
+`
+
+type GetWishListChangesParams struct {
+	WishlistID string
+	Limit      int64
+}
+
+type GetWishListChangesRow struct {
+	ID                 string
+	Kind               string
+	ElementName        string
+	ElementDescription sql.NullString
+	ElementUrl         sql.NullString
+	CreatedAt          int64
+}
+
+func (q *Queries) GetWishListChanges(ctx context.Context, arg GetWishListChangesParams) ([]GetWishListChangesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWishListChanges, arg.WishlistID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWishListChangesRow
+	for rows.Next() {
+		var i GetWishListChangesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.ElementName,
+			&i.ElementDescription,
+			&i.ElementUrl,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
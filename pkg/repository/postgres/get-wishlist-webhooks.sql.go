@@ -0,0 +1,52 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: get-wishlist-webhooks.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const getWishListWebhooks = `-- name: GetWishListWebhooks :many
+select id, url, secret, created_at
+from webhooks
+where wishlist_id = $1
+order by created_at
+`
+
+type GetWishListWebhooksRow struct {
+	ID        string
+	Url       string
+	Secret    string
+	CreatedAt int64
+}
+
+func (q *Queries) GetWishListWebhooks(ctx context.Context, wishlistID string) ([]GetWishListWebhooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWishListWebhooks, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWishListWebhooksRow
+	for rows.Next() {
+		var i GetWishListWebhooksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Secret,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
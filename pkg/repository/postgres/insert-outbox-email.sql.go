@@ -0,0 +1,42 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: insert-outbox-email.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const insertOutboxEmail = `-- name: InsertOutboxEmail :exec
+insert into email_outbox (
+    id, kind, recipient, lang, payload, status, attempts, next_attempt_at, created_at
+)
+values (
+    $1, $2, $3, $4, $5, 'pending', 0, $6, $7
+)
+`
+
+type InsertOutboxEmailParams struct {
+	ID            string
+	Kind          string
+	Recipient     string
+	Lang          string
+	Payload       string
+	NextAttemptAt int64
+	CreatedAt     int64
+}
+
+func (q *Queries) InsertOutboxEmail(ctx context.Context, arg InsertOutboxEmailParams) error {
+	_, err := q.db.ExecContext(ctx, insertOutboxEmail,
+		arg.ID,
+		arg.Kind,
+		arg.Recipient,
+		arg.Lang,
+		arg.Payload,
+		arg.NextAttemptAt,
+		arg.CreatedAt,
+	)
+	return err
+}
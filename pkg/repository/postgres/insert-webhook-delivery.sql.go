@@ -0,0 +1,45 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: insert-webhook-delivery.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+const insertWebhookDelivery = `-- name: InsertWebhookDelivery :exec
+insert into webhook_deliveries (
+    id, webhook_id, event, payload, attempt, status_code, error, created_at
+)
+values (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+`
+
+type InsertWebhookDeliveryParams struct {
+	ID         string
+	WebhookID  string
+	Event      string
+	Payload    string
+	Attempt    int64
+	StatusCode sql.NullInt64
+	Error      sql.NullString
+	CreatedAt  int64
+}
+
+func (q *Queries) InsertWebhookDelivery(ctx context.Context, arg InsertWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, insertWebhookDelivery,
+		arg.ID,
+		arg.WebhookID,
+		arg.Event,
+		arg.Payload,
+		arg.Attempt,
+		arg.StatusCode,
+		arg.Error,
+		arg.CreatedAt,
+	)
+	return err
+}
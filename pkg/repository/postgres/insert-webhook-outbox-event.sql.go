@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: insert-webhook-outbox-event.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const insertWebhookOutboxEvent = `-- name: InsertWebhookOutboxEvent :exec
+insert into webhook_outbox (
+    id, webhook_id, event, payload, attempts, next_attempt_at, created_at
+)
+values (
+    $1, $2, $3, $4, 0, $5, $6
+)
+`
+
+type InsertWebhookOutboxEventParams struct {
+	ID            string
+	WebhookID     string
+	Event         string
+	Payload       string
+	NextAttemptAt int64
+	CreatedAt     int64
+}
+
+func (q *Queries) InsertWebhookOutboxEvent(ctx context.Context, arg InsertWebhookOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, insertWebhookOutboxEvent,
+		arg.ID,
+		arg.WebhookID,
+		arg.Event,
+		arg.Payload,
+		arg.NextAttemptAt,
+		arg.CreatedAt,
+	)
+	return err
+}
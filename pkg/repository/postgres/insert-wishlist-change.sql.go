@@ -0,0 +1,48 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: insert-wishlist-change.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+const insertWishListChange = `-- name: InsertWishListChange :exec
+insert into wishlist_changes (
+    id,
+    wishlist_id,
+    kind,
+    element_name,
+    element_description,
+    element_url,
+    created_at
+) values (
+    $1, $2, $3, $4, $5, $6, $7
+)
+`
+
+type InsertWishListChangeParams struct {
+	ID                 string
+	WishlistID         string
+	Kind               string
+	ElementName        string
+	ElementDescription sql.NullString
+	ElementUrl         sql.NullString
+	CreatedAt          int64
+}
+
+func (q *Queries) InsertWishListChange(ctx context.Context, arg InsertWishListChangeParams) error {
+	_, err := q.db.ExecContext(ctx, insertWishListChange,
+		arg.ID,
+		arg.WishlistID,
+		arg.Kind,
+		arg.ElementName,
+		arg.ElementDescription,
+		arg.ElementUrl,
+		arg.CreatedAt,
+	)
+	return err
+}
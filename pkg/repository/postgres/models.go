@@ -0,0 +1,117 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+
+package postgres
+
+import (
+	"database/sql"
+)
+
+type ApiToken struct {
+	ID        string
+	UserID    string
+	Name      string
+	TokenHash string
+	ReadOnly  int64
+	CreatedAt int64
+}
+
+type EmailOutbox struct {
+	ID            string
+	Kind          string
+	Recipient     string
+	Lang          string
+	Payload       string
+	Status        string
+	Attempts      int64
+	NextAttemptAt int64
+	LastError     sql.NullString
+	CreatedAt     int64
+}
+
+type Group struct {
+	ID   string
+	Name string
+}
+
+type RateLimit struct {
+	Key         string
+	WindowStart int64
+	Hits        int64
+}
+
+type User struct {
+	ID            string
+	Name          string
+	Email         string
+	CalendarToken sql.NullString
+	Lang          string
+}
+
+type UserSession struct {
+	ID               string
+	UserID           string
+	MagicLinkToken   sql.NullString
+	CreatedAt        int64
+	LastUsedAt       int64
+	MagicLinkBinding string
+	LoggedInAt       int64
+}
+
+type Webhook struct {
+	ID         string
+	WishlistID string
+	Url        string
+	Secret     string
+	CreatedAt  int64
+}
+
+type WebhookDelivery struct {
+	ID         string
+	WebhookID  string
+	Event      string
+	Payload    string
+	Attempt    int64
+	StatusCode sql.NullInt64
+	Error      sql.NullString
+	CreatedAt  int64
+}
+
+type WebhookOutbox struct {
+	ID            string
+	WebhookID     string
+	Event         string
+	Payload       string
+	Attempts      int64
+	NextAttemptAt int64
+	CreatedAt     int64
+}
+
+type Wishlist struct {
+	ID        string
+	AdminID   string
+	UserID    string
+	Name      string
+	GroupID   sql.NullString
+	EventDate sql.NullString
+}
+
+type WishlistChange struct {
+	ID                 string
+	WishlistID         string
+	Kind               string
+	ElementName        string
+	ElementDescription sql.NullString
+	ElementUrl         sql.NullString
+	CreatedAt          int64
+	Seq                int64
+}
+
+type WishlistElement struct {
+	ID          string
+	WishlistID  string
+	Name        string
+	Description sql.NullString
+	Url         sql.NullString
+}
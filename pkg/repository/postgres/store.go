@@ -0,0 +1,440 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+// Store implements repository.Store for PostgreSQL.
+//
+// The queries are generated from the same definitions as the SQLite ones, so the types
+// are identical and only need to be converted.
+type Store struct {
+	queries *Queries
+}
+
+var _ repository.Store = (*Store)(nil)
+
+// NewStore returns a new Store using the given connection.
+func NewStore(db DBTX) *Store {
+	return &Store{queries: New(db)}
+}
+
+// WithTx returns a Store running its queries in the given transaction.
+func (s *Store) WithTx(tx *sql.Tx) repository.Store {
+	return &Store{queries: s.queries.WithTx(tx)}
+}
+
+func (s *Store) ClaimOutboxEmail(
+	ctx context.Context,
+	arg repository.ClaimOutboxEmailParams,
+) (int64, error) {
+	return s.queries.ClaimOutboxEmail(ctx, ClaimOutboxEmailParams(arg))
+}
+
+func (s *Store) ClaimWebhookOutboxEvent(
+	ctx context.Context,
+	arg repository.ClaimWebhookOutboxEventParams,
+) (int64, error) {
+	return s.queries.ClaimWebhookOutboxEvent(ctx, ClaimWebhookOutboxEventParams(arg))
+}
+
+func (s *Store) CreateGroup(ctx context.Context, arg repository.CreateGroupParams) error {
+	return s.queries.CreateGroup(ctx, CreateGroupParams(arg))
+}
+
+func (s *Store) CreateUserSession(
+	ctx context.Context,
+	arg repository.CreateUserSessionParams,
+) error {
+	return s.queries.CreateUserSession(ctx, CreateUserSessionParams(arg))
+}
+
+func (s *Store) CreateWishList(ctx context.Context, arg repository.CreateWishListParams) error {
+	return s.queries.CreateWishList(ctx, CreateWishListParams(arg))
+}
+
+func (s *Store) DeleteAPIToken(
+	ctx context.Context,
+	arg repository.DeleteAPITokenParams,
+) (int64, error) {
+	return s.queries.DeleteAPIToken(ctx, DeleteAPITokenParams(arg))
+}
+
+func (s *Store) DeleteAllUserSessions(ctx context.Context) (int64, error) {
+	return s.queries.DeleteAllUserSessions(ctx)
+}
+
+func (s *Store) DeleteExpiredRateLimits(ctx context.Context, windowStart int64) error {
+	return s.queries.DeleteExpiredRateLimits(ctx, windowStart)
+}
+
+func (s *Store) DeleteExpiredUserSessions(
+	ctx context.Context,
+	arg repository.DeleteExpiredUserSessionsParams,
+) (int64, error) {
+	return s.queries.DeleteExpiredUserSessions(ctx, DeleteExpiredUserSessionsParams(arg))
+}
+
+func (s *Store) DeleteOutboxEmail(ctx context.Context, id string) error {
+	return s.queries.DeleteOutboxEmail(ctx, id)
+}
+
+func (s *Store) DeleteUserSession(ctx context.Context, id string) error {
+	return s.queries.DeleteUserSession(ctx, id)
+}
+
+func (s *Store) DeleteUserSessions(ctx context.Context, userID string) (int64, error) {
+	return s.queries.DeleteUserSessions(ctx, userID)
+}
+
+func (s *Store) DeleteWebhook(
+	ctx context.Context,
+	arg repository.DeleteWebhookParams,
+) (int64, error) {
+	return s.queries.DeleteWebhook(ctx, DeleteWebhookParams(arg))
+}
+
+func (s *Store) DeleteWebhookDeliveries(ctx context.Context, webhookID string) error {
+	return s.queries.DeleteWebhookDeliveries(ctx, webhookID)
+}
+
+func (s *Store) DeleteWebhookOutboxEvent(ctx context.Context, id string) error {
+	return s.queries.DeleteWebhookOutboxEvent(ctx, id)
+}
+
+func (s *Store) DeleteWebhookOutboxEvents(ctx context.Context, webhookID string) error {
+	return s.queries.DeleteWebhookOutboxEvents(ctx, webhookID)
+}
+
+func (s *Store) DeleteWishList(ctx context.Context, id string) (int64, error) {
+	return s.queries.DeleteWishList(ctx, id)
+}
+
+func (s *Store) DeleteWishListChanges(ctx context.Context, wishlistID string) error {
+	return s.queries.DeleteWishListChanges(ctx, wishlistID)
+}
+
+func (s *Store) DeleteWishListElements(ctx context.Context, wishlistID string) error {
+	return s.queries.DeleteWishListElements(ctx, wishlistID)
+}
+
+func (s *Store) DeleteWishListWebhookDeliveries(ctx context.Context, wishlistID string) error {
+	return s.queries.DeleteWishListWebhookDeliveries(ctx, wishlistID)
+}
+
+func (s *Store) DeleteWishListWebhookOutboxEvents(ctx context.Context, wishlistID string) error {
+	return s.queries.DeleteWishListWebhookOutboxEvents(ctx, wishlistID)
+}
+
+func (s *Store) DeleteWishListWebhooks(ctx context.Context, wishlistID string) error {
+	return s.queries.DeleteWishListWebhooks(ctx, wishlistID)
+}
+
+func (s *Store) GetDueOutboxEmails(
+	ctx context.Context,
+	arg repository.GetDueOutboxEmailsParams,
+) ([]repository.GetDueOutboxEmailsRow, error) {
+	rows, err := s.queries.GetDueOutboxEmails(ctx, GetDueOutboxEmailsParams(arg))
+	return convertRows(rows, err, func(row GetDueOutboxEmailsRow) repository.GetDueOutboxEmailsRow {
+		return repository.GetDueOutboxEmailsRow(row)
+	})
+}
+
+func (s *Store) GetDueWebhookOutboxEvents(
+	ctx context.Context,
+	arg repository.GetDueWebhookOutboxEventsParams,
+) ([]repository.GetDueWebhookOutboxEventsRow, error) {
+	rows, err := s.queries.GetDueWebhookOutboxEvents(ctx, GetDueWebhookOutboxEventsParams(arg))
+	return convertRows(
+		rows,
+		err,
+		func(row GetDueWebhookOutboxEventsRow) repository.GetDueWebhookOutboxEventsRow {
+			return repository.GetDueWebhookOutboxEventsRow(row)
+		},
+	)
+}
+
+func (s *Store) GetFailedOutboxEmails(
+	ctx context.Context,
+) ([]repository.GetFailedOutboxEmailsRow, error) {
+	rows, err := s.queries.GetFailedOutboxEmails(ctx)
+	return convertRows(
+		rows,
+		err,
+		func(row GetFailedOutboxEmailsRow) repository.GetFailedOutboxEmailsRow {
+			return repository.GetFailedOutboxEmailsRow(row)
+		},
+	)
+}
+
+func (s *Store) GetMagicLinkBinding(
+	ctx context.Context,
+	arg repository.GetMagicLinkBindingParams,
+) (string, error) {
+	return s.queries.GetMagicLinkBinding(ctx, GetMagicLinkBindingParams(arg))
+}
+
+func (s *Store) GetOrCreateUser(
+	ctx context.Context,
+	arg repository.GetOrCreateUserParams,
+) (repository.GetOrCreateUserRow, error) {
+	row, err := s.queries.GetOrCreateUser(ctx, GetOrCreateUserParams(arg))
+	return repository.GetOrCreateUserRow(row), err
+}
+
+func (s *Store) GetRateLimitHits(
+	ctx context.Context,
+	arg repository.GetRateLimitHitsParams,
+) (int64, error) {
+	return s.queries.GetRateLimitHits(ctx, GetRateLimitHitsParams(arg))
+}
+
+func (s *Store) GetUserAPITokens(
+	ctx context.Context,
+	userID string,
+) ([]repository.GetUserAPITokensRow, error) {
+	rows, err := s.queries.GetUserAPITokens(ctx, userID)
+	return convertRows(rows, err, func(row GetUserAPITokensRow) repository.GetUserAPITokensRow {
+		return repository.GetUserAPITokensRow(row)
+	})
+}
+
+func (s *Store) GetUserByAPIToken(
+	ctx context.Context,
+	tokenHash string,
+) (repository.GetUserByAPITokenRow, error) {
+	row, err := s.queries.GetUserByAPIToken(ctx, tokenHash)
+	return repository.GetUserByAPITokenRow(row), err
+}
+
+func (s *Store) GetUserByCalendarToken(
+	ctx context.Context,
+	calendarToken sql.NullString,
+) (repository.GetUserByCalendarTokenRow, error) {
+	row, err := s.queries.GetUserByCalendarToken(ctx, calendarToken)
+	return repository.GetUserByCalendarTokenRow(row), err
+}
+
+func (s *Store) GetUserByEmail(
+	ctx context.Context,
+	email string,
+) (repository.GetUserByEmailRow, error) {
+	row, err := s.queries.GetUserByEmail(ctx, email)
+	return repository.GetUserByEmailRow(row), err
+}
+
+func (s *Store) GetUserCalendarToken(ctx context.Context, id string) (sql.NullString, error) {
+	return s.queries.GetUserCalendarToken(ctx, id)
+}
+
+func (s *Store) GetUserEventWishLists(
+	ctx context.Context,
+	userID string,
+) ([]repository.GetUserEventWishListsRow, error) {
+	rows, err := s.queries.GetUserEventWishLists(ctx, userID)
+	return convertRows(
+		rows,
+		err,
+		func(row GetUserEventWishListsRow) repository.GetUserEventWishListsRow {
+			return repository.GetUserEventWishListsRow(row)
+		},
+	)
+}
+
+func (s *Store) GetUserSession(
+	ctx context.Context,
+	id string,
+) (repository.GetUserSessionRow, error) {
+	row, err := s.queries.GetUserSession(ctx, id)
+	return repository.GetUserSessionRow(row), err
+}
+
+func (s *Store) GetUserSessionByMagicLink(
+	ctx context.Context,
+	arg repository.GetUserSessionByMagicLinkParams,
+) (repository.GetUserSessionByMagicLinkRow, error) {
+	row, err := s.queries.GetUserSessionByMagicLink(ctx, GetUserSessionByMagicLinkParams(arg))
+	return repository.GetUserSessionByMagicLinkRow(row), err
+}
+
+func (s *Store) GetUserWishLists(
+	ctx context.Context,
+	userID string,
+) ([]repository.GetUserWishListsRow, error) {
+	rows, err := s.queries.GetUserWishLists(ctx, userID)
+	return convertRows(rows, err, func(row GetUserWishListsRow) repository.GetUserWishListsRow {
+		return repository.GetUserWishListsRow(row)
+	})
+}
+
+func (s *Store) GetUsers(ctx context.Context) ([]repository.GetUsersRow, error) {
+	rows, err := s.queries.GetUsers(ctx)
+	return convertRows(rows, err, func(row GetUsersRow) repository.GetUsersRow {
+		return repository.GetUsersRow(row)
+	})
+}
+
+func (s *Store) GetWishList(ctx context.Context, id string) (repository.GetWishListRow, error) {
+	row, err := s.queries.GetWishList(ctx, id)
+	return repository.GetWishListRow(row), err
+}
+
+func (s *Store) GetWishListChanges(
+	ctx context.Context,
+	arg repository.GetWishListChangesParams,
+) ([]repository.GetWishListChangesRow, error) {
+	rows, err := s.queries.GetWishListChanges(ctx, GetWishListChangesParams(arg))
+	return convertRows(rows, err, func(row GetWishListChangesRow) repository.GetWishListChangesRow {
+		return repository.GetWishListChangesRow(row)
+	})
+}
+
+func (s *Store) GetWishListElements(
+	ctx context.Context,
+	wishlistID string,
+) ([]repository.GetWishListElementsRow, error) {
+	rows, err := s.queries.GetWishListElements(ctx, wishlistID)
+	return convertRows(
+		rows,
+		err,
+		func(row GetWishListElementsRow) repository.GetWishListElementsRow {
+			return repository.GetWishListElementsRow(row)
+		},
+	)
+}
+
+func (s *Store) GetWishListWebhookDeliveries(
+	ctx context.Context,
+	arg repository.GetWishListWebhookDeliveriesParams,
+) ([]repository.GetWishListWebhookDeliveriesRow, error) {
+	rows, err := s.queries.GetWishListWebhookDeliveries(
+		ctx,
+		GetWishListWebhookDeliveriesParams(arg),
+	)
+	return convertRows(
+		rows,
+		err,
+		func(row GetWishListWebhookDeliveriesRow) repository.GetWishListWebhookDeliveriesRow {
+			return repository.GetWishListWebhookDeliveriesRow(row)
+		},
+	)
+}
+
+func (s *Store) GetWishListWebhooks(
+	ctx context.Context,
+	wishlistID string,
+) ([]repository.GetWishListWebhooksRow, error) {
+	rows, err := s.queries.GetWishListWebhooks(ctx, wishlistID)
+	return convertRows(
+		rows,
+		err,
+		func(row GetWishListWebhooksRow) repository.GetWishListWebhooksRow {
+			return repository.GetWishListWebhooksRow(row)
+		},
+	)
+}
+
+func (s *Store) HitRateLimit(
+	ctx context.Context,
+	arg repository.HitRateLimitParams,
+) (int64, error) {
+	return s.queries.HitRateLimit(ctx, HitRateLimitParams(arg))
+}
+
+func (s *Store) InsertAPIToken(ctx context.Context, arg repository.InsertAPITokenParams) error {
+	return s.queries.InsertAPIToken(ctx, InsertAPITokenParams(arg))
+}
+
+func (s *Store) InsertOutboxEmail(
+	ctx context.Context,
+	arg repository.InsertOutboxEmailParams,
+) error {
+	return s.queries.InsertOutboxEmail(ctx, InsertOutboxEmailParams(arg))
+}
+
+func (s *Store) InsertWebhook(ctx context.Context, arg repository.InsertWebhookParams) error {
+	return s.queries.InsertWebhook(ctx, InsertWebhookParams(arg))
+}
+
+func (s *Store) InsertWebhookDelivery(
+	ctx context.Context,
+	arg repository.InsertWebhookDeliveryParams,
+) error {
+	return s.queries.InsertWebhookDelivery(ctx, InsertWebhookDeliveryParams(arg))
+}
+
+func (s *Store) InsertWebhookOutboxEvent(
+	ctx context.Context,
+	arg repository.InsertWebhookOutboxEventParams,
+) error {
+	return s.queries.InsertWebhookOutboxEvent(ctx, InsertWebhookOutboxEventParams(arg))
+}
+
+func (s *Store) InsertWishListChange(
+	ctx context.Context,
+	arg repository.InsertWishListChangeParams,
+) error {
+	return s.queries.InsertWishListChange(ctx, InsertWishListChangeParams(arg))
+}
+
+func (s *Store) InsertWishListElement(
+	ctx context.Context,
+	arg repository.InsertWishListElementParams,
+) error {
+	return s.queries.InsertWishListElement(ctx, InsertWishListElementParams(arg))
+}
+
+func (s *Store) RetryOutboxEmail(
+	ctx context.Context,
+	arg repository.RetryOutboxEmailParams,
+) (int64, error) {
+	return s.queries.RetryOutboxEmail(ctx, RetryOutboxEmailParams(arg))
+}
+
+func (s *Store) SetUserCalendarToken(
+	ctx context.Context,
+	arg repository.SetUserCalendarTokenParams,
+) error {
+	return s.queries.SetUserCalendarToken(ctx, SetUserCalendarTokenParams(arg))
+}
+
+func (s *Store) TouchUserSession(
+	ctx context.Context,
+	arg repository.TouchUserSessionParams,
+) error {
+	return s.queries.TouchUserSession(ctx, TouchUserSessionParams(arg))
+}
+
+func (s *Store) UpdateOutboxEmailAttempt(
+	ctx context.Context,
+	arg repository.UpdateOutboxEmailAttemptParams,
+) error {
+	return s.queries.UpdateOutboxEmailAttempt(ctx, UpdateOutboxEmailAttemptParams(arg))
+}
+
+func (s *Store) UpdateWebhookOutboxEventAttempt(
+	ctx context.Context,
+	arg repository.UpdateWebhookOutboxEventAttemptParams,
+) error {
+	return s.queries.UpdateWebhookOutboxEventAttempt(
+		ctx,
+		UpdateWebhookOutboxEventAttemptParams(arg),
+	)
+}
+
+// convertRows converts the rows returned by a query to their repository type.
+func convertRows[From any, To any](rows []From, err error, convert func(From) To) ([]To, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]To, 0, len(rows))
+	for _, row := range rows {
+		converted = append(converted, convert(row))
+	}
+
+	return converted, nil
+}
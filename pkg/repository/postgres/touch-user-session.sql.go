@@ -0,0 +1,26 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: touch-user-session.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const touchUserSession = `-- name: TouchUserSession :exec
+update user_sessions
+set last_used_at = $1
+where id = $2
+`
+
+type TouchUserSessionParams struct {
+	LastUsedAt int64
+	ID         string
+}
+
+func (q *Queries) TouchUserSession(ctx context.Context, arg TouchUserSessionParams) error {
+	_, err := q.db.ExecContext(ctx, touchUserSession, arg.LastUsedAt, arg.ID)
+	return err
+}
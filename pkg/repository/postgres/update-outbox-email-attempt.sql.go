@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: update-outbox-email-attempt.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateOutboxEmailAttempt = `-- name: UpdateOutboxEmailAttempt :exec
+update email_outbox
+set status = $1, attempts = $2, next_attempt_at = $3, last_error = $4
+where id = $5
+`
+
+type UpdateOutboxEmailAttemptParams struct {
+	Status        string
+	Attempts      int64
+	NextAttemptAt int64
+	LastError     sql.NullString
+	ID            string
+}
+
+func (q *Queries) UpdateOutboxEmailAttempt(ctx context.Context, arg UpdateOutboxEmailAttemptParams) error {
+	_, err := q.db.ExecContext(ctx, updateOutboxEmailAttempt,
+		arg.Status,
+		arg.Attempts,
+		arg.NextAttemptAt,
+		arg.LastError,
+		arg.ID,
+	)
+	return err
+}
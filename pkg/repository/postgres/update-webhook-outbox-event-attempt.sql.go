@@ -0,0 +1,27 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: update-webhook-outbox-event-attempt.sql
+
+package postgres
+
+import (
+	"context"
+)
+
+const updateWebhookOutboxEventAttempt = `-- name: UpdateWebhookOutboxEventAttempt :exec
+update webhook_outbox
+set attempts = $1, next_attempt_at = $2
+where id = $3
+`
+
+type UpdateWebhookOutboxEventAttemptParams struct {
+	Attempts      int64
+	NextAttemptAt int64
+	ID            string
+}
+
+func (q *Queries) UpdateWebhookOutboxEventAttempt(ctx context.Context, arg UpdateWebhookOutboxEventAttemptParams) error {
+	_, err := q.db.ExecContext(ctx, updateWebhookOutboxEventAttempt, arg.Attempts, arg.NextAttemptAt, arg.ID)
+	return err
+}
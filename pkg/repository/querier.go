@@ -0,0 +1,71 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Querier interface {
+	ClaimOutboxEmail(ctx context.Context, arg ClaimOutboxEmailParams) (int64, error)
+	ClaimWebhookOutboxEvent(ctx context.Context, arg ClaimWebhookOutboxEventParams) (int64, error)
+	CreateGroup(ctx context.Context, arg CreateGroupParams) error
+	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) error
+	CreateWishList(ctx context.Context, arg CreateWishListParams) error
+	DeleteAPIToken(ctx context.Context, arg DeleteAPITokenParams) (int64, error)
+	DeleteAllUserSessions(ctx context.Context) (int64, error)
+	DeleteExpiredRateLimits(ctx context.Context, windowStart int64) error
+	DeleteExpiredUserSessions(ctx context.Context, arg DeleteExpiredUserSessionsParams) (int64, error)
+	DeleteOutboxEmail(ctx context.Context, id string) error
+	DeleteUserSession(ctx context.Context, id string) error
+	DeleteUserSessions(ctx context.Context, userID string) (int64, error)
+	DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) (int64, error)
+	DeleteWebhookDeliveries(ctx context.Context, webhookID string) error
+	DeleteWebhookOutboxEvent(ctx context.Context, id string) error
+	DeleteWebhookOutboxEvents(ctx context.Context, webhookID string) error
+	DeleteWishList(ctx context.Context, id string) (int64, error)
+	DeleteWishListChanges(ctx context.Context, wishlistID string) error
+	DeleteWishListElements(ctx context.Context, wishlistID string) error
+	DeleteWishListWebhookDeliveries(ctx context.Context, wishlistID string) error
+	DeleteWishListWebhookOutboxEvents(ctx context.Context, wishlistID string) error
+	DeleteWishListWebhooks(ctx context.Context, wishlistID string) error
+	GetDueOutboxEmails(ctx context.Context, arg GetDueOutboxEmailsParams) ([]GetDueOutboxEmailsRow, error)
+	GetDueWebhookOutboxEvents(ctx context.Context, arg GetDueWebhookOutboxEventsParams) ([]GetDueWebhookOutboxEventsRow, error)
+	GetFailedOutboxEmails(ctx context.Context) ([]GetFailedOutboxEmailsRow, error)
+	GetMagicLinkBinding(ctx context.Context, arg GetMagicLinkBindingParams) (string, error)
+	GetOrCreateUser(ctx context.Context, arg GetOrCreateUserParams) (GetOrCreateUserRow, error)
+	GetRateLimitHits(ctx context.Context, arg GetRateLimitHitsParams) (int64, error)
+	GetUserAPITokens(ctx context.Context, userID string) ([]GetUserAPITokensRow, error)
+	GetUserByAPIToken(ctx context.Context, tokenHash string) (GetUserByAPITokenRow, error)
+	GetUserByCalendarToken(ctx context.Context, calendarToken sql.NullString) (GetUserByCalendarTokenRow, error)
+	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
+	GetUserCalendarToken(ctx context.Context, id string) (sql.NullString, error)
+	GetUserEventWishLists(ctx context.Context, userID string) ([]GetUserEventWishListsRow, error)
+	GetUserSession(ctx context.Context, id string) (GetUserSessionRow, error)
+	GetUserSessionByMagicLink(ctx context.Context, arg GetUserSessionByMagicLinkParams) (GetUserSessionByMagicLinkRow, error)
+	GetUserWishLists(ctx context.Context, userID string) ([]GetUserWishListsRow, error)
+	GetUsers(ctx context.Context) ([]GetUsersRow, error)
+	GetWishList(ctx context.Context, id string) (GetWishListRow, error)
+	GetWishListChanges(ctx context.Context, arg GetWishListChangesParams) ([]GetWishListChangesRow, error)
+	GetWishListElements(ctx context.Context, wishlistID string) ([]GetWishListElementsRow, error)
+	GetWishListWebhookDeliveries(ctx context.Context, arg GetWishListWebhookDeliveriesParams) ([]GetWishListWebhookDeliveriesRow, error)
+	GetWishListWebhooks(ctx context.Context, wishlistID string) ([]GetWishListWebhooksRow, error)
+	HitRateLimit(ctx context.Context, arg HitRateLimitParams) (int64, error)
+	InsertAPIToken(ctx context.Context, arg InsertAPITokenParams) error
+	InsertOutboxEmail(ctx context.Context, arg InsertOutboxEmailParams) error
+	InsertWebhook(ctx context.Context, arg InsertWebhookParams) error
+	InsertWebhookDelivery(ctx context.Context, arg InsertWebhookDeliveryParams) error
+	InsertWebhookOutboxEvent(ctx context.Context, arg InsertWebhookOutboxEventParams) error
+	InsertWishListChange(ctx context.Context, arg InsertWishListChangeParams) error
+	InsertWishListElement(ctx context.Context, arg InsertWishListElementParams) error
+	RetryOutboxEmail(ctx context.Context, arg RetryOutboxEmailParams) (int64, error)
+	SetUserCalendarToken(ctx context.Context, arg SetUserCalendarTokenParams) error
+	TouchUserSession(ctx context.Context, arg TouchUserSessionParams) error
+	UpdateOutboxEmailAttempt(ctx context.Context, arg UpdateOutboxEmailAttemptParams) error
+	UpdateWebhookOutboxEventAttempt(ctx context.Context, arg UpdateWebhookOutboxEventAttemptParams) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,30 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: retry-outbox-email.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const retryOutboxEmail = `-- name: RetryOutboxEmail :execrows
+update email_outbox
+set status = 'pending', attempts = 0, next_attempt_at = ?
+where id = ?
+    and status = 'failed'
+`
+
+type RetryOutboxEmailParams struct {
+	NextAttemptAt int64
+	ID            string
+}
+
+func (q *Queries) RetryOutboxEmail(ctx context.Context, arg RetryOutboxEmailParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, retryOutboxEmail, arg.NextAttemptAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
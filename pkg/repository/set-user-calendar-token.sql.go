@@ -0,0 +1,27 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: set-user-calendar-token.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const setUserCalendarToken = `-- name: SetUserCalendarToken :exec
+update users
+set calendar_token = ?
+where id = ?
+`
+
+type SetUserCalendarTokenParams struct {
+	CalendarToken sql.NullString
+	ID            string
+}
+
+func (q *Queries) SetUserCalendarToken(ctx context.Context, arg SetUserCalendarTokenParams) error {
+	_, err := q.db.ExecContext(ctx, setUserCalendarToken, arg.CalendarToken, arg.ID)
+	return err
+}
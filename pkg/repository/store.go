@@ -0,0 +1,27 @@
+package repository
+
+import "database/sql"
+
+// Store gives access to the queries of the app.
+//
+// It is implemented for each supported database engine: by this package for SQLite,
+// and by the postgres package for PostgreSQL.
+type Store interface {
+	Querier
+
+	// WithTx returns a Store running its queries in the given transaction.
+	WithTx(tx *sql.Tx) Store
+}
+
+type sqliteStore struct {
+	*Queries
+}
+
+// NewStore returns a new Store for SQLite using the given connection.
+func NewStore(db DBTX) Store {
+	return sqliteStore{Queries: New(db)}
+}
+
+func (s sqliteStore) WithTx(tx *sql.Tx) Store {
+	return sqliteStore{Queries: s.Queries.WithTx(tx)}
+}
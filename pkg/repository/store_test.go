@@ -0,0 +1,537 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+func createUser(t *testing.T, store repository.Store, id string, email string) {
+	t.Helper()
+
+	_, err := store.GetOrCreateUser(context.Background(), repository.GetOrCreateUserParams{
+		ID:    id,
+		Name:  "Alice",
+		Email: email,
+		Lang:  "fr",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createWishList(t *testing.T, store repository.Store, id string, userID string) {
+	t.Helper()
+
+	err := store.CreateWishList(context.Background(), repository.CreateWishListParams{
+		ID:        id,
+		AdminID:   id + "-admin",
+		Name:      "Birthday",
+		UserID:    userID,
+		EventDate: wishlister.NewNullString("2026-12-25"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoreGetOrCreateUser(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+		createUser(t, store, "user1", "alice@example.com")
+
+		// The existing user is returned, with its new name and its known language.
+		user, err := store.GetOrCreateUser(ctx, repository.GetOrCreateUserParams{
+			ID:    "user2",
+			Name:  "Alice B.",
+			Email: "alice@example.com",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := repository.GetOrCreateUserRow{
+			ID:    "user1",
+			Name:  "Alice B.",
+			Email: "alice@example.com",
+			Lang:  "fr",
+		}
+		if user != expected {
+			t.Errorf("got %+v, expected %+v", user, expected)
+		}
+
+		_, err = store.GetUserByEmail(ctx, "bob@example.com")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("got error %v, expected %v", err, sql.ErrNoRows)
+		}
+	})
+}
+
+func TestStoreWishList(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+		createUser(t, store, "user1", "alice@example.com")
+		createWishList(t, store, "list1", "user1")
+
+		err := store.InsertWishListElement(ctx, repository.InsertWishListElementParams{
+			ID:          "element1",
+			WishlistID:  "list1",
+			Name:        "Book",
+			Description: wishlister.NewNullString("A good one"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		list, err := store.GetWishList(ctx, "list1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := repository.GetWishListRow{
+			ID:        "list1",
+			AdminID:   "list1-admin",
+			Name:      "Birthday",
+			EventDate: wishlister.NewNullString("2026-12-25"),
+			Username:  "Alice",
+		}
+		if list != expected {
+			t.Errorf("got %+v, expected %+v", list, expected)
+		}
+
+		elements, err := store.GetWishListElements(ctx, "list1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(elements) != 1 || elements[0].Name != "Book" ||
+			elements[0].Description.String != "A good one" || elements[0].Url.Valid {
+			t.Errorf("got elements %+v", elements)
+		}
+
+		events, err := store.GetUserEventWishLists(ctx, "user1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(events) != 1 || events[0].ID != "list1" {
+			t.Errorf("got event wishlists %+v", events)
+		}
+	})
+}
+
+// TestStoreDeleteWishList checks the order used to delete a wishlist, as PostgreSQL
+// enforces the foreign keys.
+func TestStoreDeleteWishList(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+		createUser(t, store, "user1", "alice@example.com")
+		createWishList(t, store, "list1", "user1")
+
+		err := store.InsertWishListElement(ctx, repository.InsertWishListElementParams{
+			ID:         "element1",
+			WishlistID: "list1",
+			Name:       "Book",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = store.InsertWishListChange(ctx, repository.InsertWishListChangeParams{
+			ID:          "change1",
+			WishlistID:  "list1",
+			Kind:        "added",
+			ElementName: "Book",
+			CreatedAt:   1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = store.InsertWebhook(ctx, repository.InsertWebhookParams{
+			ID:         "webhook1",
+			WishlistID: "list1",
+			Url:        "https://example.com/hook",
+			Secret:     "secret",
+			CreatedAt:  1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = store.InsertWebhookDelivery(ctx, repository.InsertWebhookDeliveryParams{
+			ID:         "delivery1",
+			WebhookID:  "webhook1",
+			Event:      "element.added",
+			Payload:    "{}",
+			Attempt:    1,
+			StatusCode: sql.NullInt64{Int64: 200, Valid: true},
+			CreatedAt:  1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, deleteFunc := range []func(context.Context, string) error{
+			store.DeleteWishListWebhookDeliveries,
+			store.DeleteWishListWebhooks,
+			store.DeleteWishListChanges,
+			store.DeleteWishListElements,
+		} {
+			err = deleteFunc(ctx, "list1")
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		count, err := store.DeleteWishList(ctx, "list1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 1 {
+			t.Errorf("got %d deleted wishlists, expected 1", count)
+		}
+
+		_, err = store.GetWishList(ctx, "list1")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("got error %v, expected %v", err, sql.ErrNoRows)
+		}
+	})
+}
+
+func TestStoreMagicLink(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+		createUser(t, store, "user1", "alice@example.com")
+
+		err := store.CreateUserSession(ctx, repository.CreateUserSessionParams{
+			ID:               "session1",
+			UserID:           "user1",
+			MagicLinkToken:   wishlister.NewNullString("token"),
+			MagicLinkBinding: "binding",
+			CreatedAt:        100,
+			LastUsedAt:       100,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params := repository.GetUserSessionByMagicLinkParams{
+			LoggedInAt:       150,
+			MagicLinkToken:   wishlister.NewNullString("token"),
+			MagicLinkBinding: "other",
+			CreatedAfter:     50,
+		}
+		_, err = store.GetUserSessionByMagicLink(ctx, params)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("wrong binding: got error %v, expected %v", err, sql.ErrNoRows)
+		}
+
+		params.MagicLinkBinding = "binding"
+		params.CreatedAfter = 101
+		_, err = store.GetUserSessionByMagicLink(ctx, params)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expired link: got error %v, expected %v", err, sql.ErrNoRows)
+		}
+
+		params.CreatedAfter = 50
+		session, err := store.GetUserSessionByMagicLink(ctx, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The lifetime of the session starts when the link is used.
+		if session.ID != "session1" || session.UserID != "user1" || session.LoggedInAt != 150 {
+			t.Errorf("got session %+v", session)
+		}
+
+		// A magic link is used only once.
+		_, err = store.GetUserSessionByMagicLink(ctx, params)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("used link: got error %v, expected %v", err, sql.ErrNoRows)
+		}
+
+		userSession, err := store.GetUserSession(ctx, "session1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if userSession.LastUsedAt != 150 || userSession.UserEmail != "alice@example.com" {
+			t.Errorf("got session %+v", userSession)
+		}
+	})
+}
+
+func TestStoreDeleteExpiredUserSessions(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+		createUser(t, store, "user1", "alice@example.com")
+
+		// The sessions are logged in with their magic link, which starts their lifetime.
+		for _, session := range []struct {
+			id         string
+			createdAt  int64
+			loggedInAt int64
+			lastUsedAt int64
+		}{
+			{id: "valid", createdAt: 100, loggedInAt: 100, lastUsedAt: 100},
+			// The magic link was sent long ago, but used recently.
+			{id: "late-login", createdAt: 10, loggedInAt: 100, lastUsedAt: 100},
+			{id: "too-old", createdAt: 10, loggedInAt: 10, lastUsedAt: 100},
+			{id: "idle", createdAt: 100, loggedInAt: 100, lastUsedAt: 10},
+		} {
+			err := store.CreateUserSession(ctx, repository.CreateUserSessionParams{
+				ID:             session.id,
+				UserID:         "user1",
+				MagicLinkToken: wishlister.NewNullString(session.id),
+				CreatedAt:      session.createdAt,
+				LastUsedAt:     session.createdAt,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = store.GetUserSessionByMagicLink(
+				ctx,
+				repository.GetUserSessionByMagicLinkParams{
+					LoggedInAt:     session.loggedInAt,
+					MagicLinkToken: wishlister.NewNullString(session.id),
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = store.TouchUserSession(ctx, repository.TouchUserSessionParams{
+				LastUsedAt: session.lastUsedAt,
+				ID:         session.id,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err := store.CreateUserSession(ctx, repository.CreateUserSessionParams{
+			ID:             "unused-link",
+			UserID:         "user1",
+			MagicLinkToken: wishlister.NewNullString("token"),
+			CreatedAt:      60,
+			LastUsedAt:     60,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := store.DeleteExpiredUserSessions(
+			ctx,
+			repository.DeleteExpiredUserSessionsParams{
+				LoggedInBefore:         50,
+				LastUsedBefore:         50,
+				MagicLinkCreatedBefore: 90,
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 3 {
+			t.Errorf("got %d deleted sessions, expected 3", count)
+		}
+
+		for _, id := range []string{"valid", "late-login"} {
+			_, err = store.GetUserSession(ctx, id)
+			if err != nil {
+				t.Errorf("the session %s was deleted: %v", id, err)
+			}
+		}
+	})
+}
+
+func TestStoreHitRateLimit(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+
+		for i, test := range []struct {
+			windowStart int64
+			hits        int64
+		}{
+			{windowStart: 100, hits: 1},
+			{windowStart: 100, hits: 2},
+			{windowStart: 200, hits: 1},
+		} {
+			hits, err := store.HitRateLimit(ctx, repository.HitRateLimitParams{
+				Key:         "email:alice@example.com",
+				WindowStart: test.windowStart,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hits != test.hits {
+				t.Errorf("hit %d: got %d hits, expected %d", i, hits, test.hits)
+			}
+		}
+
+		err := store.DeleteExpiredRateLimits(ctx, 300)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hits, err := store.HitRateLimit(ctx, repository.HitRateLimitParams{
+			Key:         "email:alice@example.com",
+			WindowStart: 200,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hits != 1 {
+			t.Errorf("got %d hits after the cleanup, expected 1", hits)
+		}
+
+		hits, err = store.GetRateLimitHits(ctx, repository.GetRateLimitHitsParams{
+			Key:         "email:alice@example.com",
+			WindowStart: 200,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hits != 1 {
+			t.Errorf("got %d hits, expected 1", hits)
+		}
+
+		_, err = store.GetRateLimitHits(ctx, repository.GetRateLimitHitsParams{
+			Key:         "email:alice@example.com",
+			WindowStart: 300,
+		})
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("got %v for another window, expected sql.ErrNoRows", err)
+		}
+	})
+}
+
+func TestStoreOutbox(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+
+		err := store.InsertOutboxEmail(ctx, repository.InsertOutboxEmailParams{
+			ID:            "email1",
+			Kind:          "magic_link",
+			Recipient:     "alice@example.com",
+			Lang:          "fr",
+			Payload:       "{}",
+			NextAttemptAt: 100,
+			CreatedAt:     100,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		emails, err := store.GetDueOutboxEmails(ctx, repository.GetDueOutboxEmailsParams{
+			NextAttemptAt: 99,
+			Limit:         10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(emails) != 0 {
+			t.Errorf("got %d due emails before their time, expected 0", len(emails))
+		}
+
+		emails, err = store.GetDueOutboxEmails(ctx, repository.GetDueOutboxEmailsParams{
+			NextAttemptAt: 100,
+			Limit:         10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(emails) != 1 || emails[0].ID != "email1" {
+			t.Fatalf("got due emails %+v", emails)
+		}
+
+		// Only one worker claims the email.
+		for i, expected := range []int64{1, 0} {
+			count, err := store.ClaimOutboxEmail(ctx, repository.ClaimOutboxEmailParams{
+				ClaimedUntil:  200,
+				ID:            "email1",
+				NextAttemptAt: emails[0].NextAttemptAt,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count != expected {
+				t.Errorf("claim %d: got %d rows, expected %d", i, count, expected)
+			}
+		}
+	})
+}
+
+func TestStoreAPITokens(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		store := dbtest.Store(db, engine)
+		createUser(t, store, "user1", "alice@example.com")
+
+		err := store.InsertAPIToken(ctx, repository.InsertAPITokenParams{
+			ID:        "token1",
+			UserID:    "user1",
+			Name:      "CLI",
+			TokenHash: "hash",
+			ReadOnly:  1,
+			CreatedAt: 100,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		user, err := store.GetUserByAPIToken(ctx, "hash")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if user.ID != "user1" || user.ReadOnly != 1 {
+			t.Errorf("got user %+v", user)
+		}
+
+		count, err := store.DeleteAPIToken(ctx, repository.DeleteAPITokenParams{
+			ID:     "token1",
+			UserID: "user2",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 0 {
+			t.Errorf("a token was deleted by another user")
+		}
+
+		count, err = store.DeleteAPIToken(ctx, repository.DeleteAPITokenParams{
+			ID:     "token1",
+			UserID: "user1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 1 {
+			t.Errorf("got %d deleted tokens, expected 1", count)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: update-outbox-email-attempt.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateOutboxEmailAttempt = `-- name: UpdateOutboxEmailAttempt :exec
+update email_outbox
+set status = ?, attempts = ?, next_attempt_at = ?, last_error = ?
+where id = ?
+`
+
+type UpdateOutboxEmailAttemptParams struct {
+	Status        string
+	Attempts      int64
+	NextAttemptAt int64
+	LastError     sql.NullString
+	ID            string
+}
+
+func (q *Queries) UpdateOutboxEmailAttempt(ctx context.Context, arg UpdateOutboxEmailAttemptParams) error {
+	_, err := q.db.ExecContext(ctx, updateOutboxEmailAttempt,
+		arg.Status,
+		arg.Attempts,
+		arg.NextAttemptAt,
+		arg.LastError,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,281 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+// apiError is the body of all the API errors responses.
+type apiError struct {
+	Error apiErrorBody `json:"error"`
+}
+
+type apiErrorBody struct {
+	// Code is a stable identifier of the error, to be used by clients.
+	Code string `json:"code"`
+	// Message is a human readable description of the error.
+	Message string `json:"message"`
+	// Fields holds the validation error of each invalid field, if any.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// ErrAPIUnauthorized is the error when an API call requires a session but none was
+// provided.
+var ErrAPIUnauthorized = errors.New("missing session")
+
+// ErrAPIForbidden is the error when an API call tries to access the resources of
+// another user.
+var ErrAPIForbidden = errors.New("access denied")
+
+// ErrAPIReadOnly is the error when an API call tries to modify something with a
+// read-only API token.
+var ErrAPIReadOnly = errors.New("read-only token")
+
+func (s Server) setAPIRoutes() {
+	s.router.Get("/api/openapi.json", s.getOpenAPI)
+	s.router.Route(apiPrefix, func(r chi.Router) {
+		r.Post("/lists", s.apiCreateWishList)
+		r.Get("/lists/{listID}", s.apiGetWishList)
+		r.Get("/lists/{listID}/changes", s.apiGetWishListChanges)
+		r.Get("/lists/{listID}/{adminID}", s.apiGetEditableWishList)
+		r.Put("/lists/{listID}/{adminID}/elements", s.apiUpdateListElements)
+		r.Get("/lists/{listID}/{adminID}/webhooks", s.apiGetWebhooks)
+		r.Post("/lists/{listID}/{adminID}/webhooks", s.apiAddWebhook)
+		r.Get("/lists/{listID}/{adminID}/webhooks/deliveries", s.apiGetWebhookDeliveries)
+		r.Delete("/lists/{listID}/{adminID}/webhooks/{webhookID}", s.apiDeleteWebhook)
+
+		r.Post("/groups", s.apiCreateGroup)
+
+		r.Post("/magic-links", s.apiSendMagicLink)
+		r.Post("/sessions", s.apiCreateSession)
+		r.Get("/session", s.apiGetSession)
+		r.Delete("/session", s.apiDeleteSession)
+
+		r.Get("/users/{userID}/lists", s.apiGetUserWishLists)
+		r.Get("/users/{userID}/calendar-token", s.apiGetUserCalendarToken)
+		r.Post("/users/{userID}/calendar-token", s.apiResetUserCalendarToken)
+		r.Get("/users/{userID}/tokens", s.apiGetAPITokens)
+		r.Post("/users/{userID}/tokens", s.apiCreateAPIToken)
+		r.Delete("/users/{userID}/tokens/{tokenID}", s.apiDeleteAPIToken)
+		r.Get("/calendars/{token}/lists", s.apiGetCalendarWishLists)
+
+		r.NotFound(func(w http.ResponseWriter, _ *http.Request) {
+			s.writeAPIErrorCode(w, http.StatusNotFound, "not_found", "unknown endpoint")
+		})
+		r.MethodNotAllowed(func(w http.ResponseWriter, _ *http.Request) {
+			s.writeAPIErrorCode(
+				w,
+				http.StatusMethodNotAllowed,
+				"method_not_allowed",
+				"method not allowed",
+			)
+		})
+	})
+}
+
+// writeJSON writes the given data as the JSON body of the response.
+func (s Server) writeJSON(w http.ResponseWriter, code int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	err := json.NewEncoder(w).Encode(data)
+	if err != nil {
+		s.logger.Error("error while writing JSON response", "err", err)
+	}
+}
+
+// writeAPIError writes the JSON error body corresponding to the given error.
+//
+// Unknown errors are logged and returned as internal errors, without any details.
+func (s Server) writeAPIError(w http.ResponseWriter, err error) {
+	code, errCode, message := http.StatusInternalServerError, "internal_error", "internal error"
+	// The validation errors of the app are reported like the ones of the validator,
+	// so the clients handle them the same way.
+	var fields map[string]string
+
+	switch {
+	case errors.Is(err, wishlister.ErrWishListNotFound):
+		code, errCode = http.StatusNotFound, "wishlist_not_found"
+	case errors.Is(err, wishlister.ErrWishListInvalidAdminID):
+		code, errCode = http.StatusForbidden, "invalid_admin_id"
+	case errors.Is(err, wishlister.ErrWishListNameEmpty):
+		code, errCode = http.StatusUnprocessableEntity, "validation_error"
+		fields = map[string]string{"name": requiredFieldMessage}
+	case errors.Is(err, wishlister.ErrWishListUsernameEmpty):
+		code, errCode = http.StatusUnprocessableEntity, "validation_error"
+		fields = map[string]string{"username": requiredFieldMessage}
+	case errors.Is(err, wishlister.ErrAPITokenNameEmpty):
+		code, errCode = http.StatusUnprocessableEntity, "validation_error"
+		fields = map[string]string{"name": requiredFieldMessage}
+	case errors.Is(err, wishlister.ErrWebhookInvalidURL):
+		code, errCode = http.StatusUnprocessableEntity, "validation_error"
+		fields = map[string]string{"url": invalidURLFieldMessage}
+	// The API cannot confirm a login, so a magic link asked from the web UI is reported
+	// as not found.
+	case errors.Is(err, wishlister.ErrSessionNotFound),
+		errors.Is(err, wishlister.ErrLoginBindingMismatch):
+		code, errCode = http.StatusUnauthorized, "session_not_found"
+	case errors.Is(err, ErrAPIUnauthorized):
+		code, errCode = http.StatusUnauthorized, "unauthorized"
+	case errors.Is(err, ErrAPIForbidden):
+		code, errCode = http.StatusForbidden, "forbidden"
+	case errors.Is(err, ErrAPIReadOnly):
+		code, errCode = http.StatusForbidden, "read_only"
+	case errors.Is(err, wishlister.ErrCalendarNotFound):
+		code, errCode = http.StatusNotFound, "calendar_not_found"
+	case errors.Is(err, wishlister.ErrWebhookNotFound):
+		code, errCode = http.StatusNotFound, "webhook_not_found"
+	case errors.Is(err, wishlister.ErrAPITokenNotFound):
+		code, errCode = http.StatusNotFound, "api_token_not_found"
+	case errors.Is(err, wishlister.ErrRateLimited):
+		code, errCode = http.StatusTooManyRequests, "rate_limited"
+	default:
+		s.logger.Error("error during API call", "err", err)
+	}
+
+	if code != http.StatusInternalServerError {
+		message = err.Error()
+	}
+
+	s.writeJSON(w, code, apiError{Error: apiErrorBody{
+		Code:    errCode,
+		Message: message,
+		Fields:  fields,
+	}})
+}
+
+func (s Server) writeAPIErrorCode(w http.ResponseWriter, code int, errCode string, message string) {
+	s.writeJSON(w, code, apiError{Error: apiErrorBody{Code: errCode, Message: message}})
+}
+
+// writeAPIValidationError writes the JSON error body corresponding to a validation
+// error returned by the validator.
+func (s Server) writeAPIValidationError(w http.ResponseWriter, err error) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	fields := make(map[string]string, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		fields[apiFieldName(fieldErr)] = apiValidationMessage(fieldErr)
+	}
+
+	s.writeJSON(w, http.StatusUnprocessableEntity, apiError{Error: apiErrorBody{
+		Code:    "validation_error",
+		Message: "invalid request",
+		Fields:  fields,
+	}})
+}
+
+// readJSON decodes the JSON body of the request into data, and validates it.
+//
+// If the body is invalid, an error response is written and false is returned.
+func (s Server) readJSON(w http.ResponseWriter, r *http.Request, data any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(data)
+	if err != nil {
+		s.writeAPIErrorCode(w, http.StatusBadRequest, "invalid_json", err.Error())
+		return false
+	}
+
+	err = s.validate.Struct(data)
+	if err != nil {
+		s.writeAPIValidationError(w, err)
+		return false
+	}
+
+	return true
+}
+
+// getAPISession returns the session of the API caller.
+//
+// A session ID or an API token must be given in the Authorization header, as a bearer
+// token. Sessions opened with a read-only API token are refused for methods other than
+// GET.
+func (s Server) getAPISession(r *http.Request) (wishlister.Session, error) {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || bearer == "" {
+		return wishlister.Session{}, ErrAPIUnauthorized
+	}
+
+	if !strings.HasPrefix(bearer, wishlister.APITokenPrefix) {
+		return s.wishlister.GetSession(r.Context(), bearer)
+	}
+
+	session, err := s.wishlister.GetSessionByAPIToken(r.Context(), bearer)
+	if err != nil {
+		return wishlister.Session{}, err
+	}
+
+	if session.ReadOnly && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return wishlister.Session{}, ErrAPIReadOnly
+	}
+
+	return session, nil
+}
+
+// getAPIUserSession returns the session of the API caller, and checks that it belongs
+// to the user in the URL.
+func (s Server) getAPIUserSession(r *http.Request) (wishlister.Session, error) {
+	session, err := s.getAPISession(r)
+	if err != nil {
+		return wishlister.Session{}, err
+	}
+
+	if session.UserID != chi.URLParam(r, "userID") {
+		return wishlister.Session{}, ErrAPIForbidden
+	}
+
+	return session, nil
+}
+
+// apiFieldName returns the name of the invalid field, as it appears in the JSON body.
+func apiFieldName(fieldErr validator.FieldError) string {
+	// The namespace starts with the name of the struct, which is meaningless for the
+	// client.
+	_, name, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+
+	return name
+}
+
+// The messages of the invalid fields used by the clients to recognize the errors.
+const (
+	requiredFieldMessage   = "this field is required"
+	invalidURLFieldMessage = "this field must be a valid HTTP URL"
+)
+
+func apiValidationMessage(fieldErr validator.FieldError) string {
+	// Tags combined with "|" are returned as a whole, we only look at the first one.
+	tag, _, _ := strings.Cut(fieldErr.Tag(), "=")
+
+	switch tag {
+	case "required":
+		return requiredFieldMessage
+	case "max":
+		return "this field must not exceed " + fieldErr.Param() + " characters"
+	case "email":
+		return "this field must be a valid email address"
+	case "url", "startswith":
+		return invalidURLFieldMessage
+	case "datetime":
+		return "this field must be a date formatted as " + fieldErr.Param()
+	case "bcp47_language_tag":
+		return "this field must be a language tag like \"en\""
+	default:
+		return "this field is invalid"
+	}
+}
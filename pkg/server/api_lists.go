@@ -0,0 +1,290 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+type apiWishList struct {
+	ID        string       `json:"id"`
+	AdminID   string       `json:"admin_id,omitempty"`
+	Name      string       `json:"name"`
+	GroupID   string       `json:"group_id,omitempty"`
+	Username  string       `json:"username,omitempty"`
+	EventDate string       `json:"event_date,omitempty"`
+	Elements  []apiElement `json:"elements,omitempty"`
+}
+
+type apiElement struct {
+	Name        string `json:"name"                  validate:"required,max=255"`
+	Description string `json:"description,omitempty" validate:"max=500"`
+	URL         string `json:"url,omitempty"         validate:"omitempty,startswith=https://|startswith=http://,url,max=2000"`
+}
+
+type apiWishListChange struct {
+	ID      string     `json:"id"`
+	Kind    string     `json:"kind"`
+	Element apiElement `json:"element"`
+	Time    time.Time  `json:"time"`
+}
+
+type apiWebhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type apiWebhookDelivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	URL        string    `json:"url"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+type apiCreateWishListRequest struct {
+	Name        string   `json:"name"                   validate:"required,max=255"`
+	Username    string   `json:"username"               validate:"required,max=255"`
+	UserEmail   string   `json:"user_email,omitempty"   validate:"omitempty,email,max=255"`
+	EventDate   string   `json:"event_date,omitempty"   validate:"omitempty,datetime=2006-01-02"`
+	Lang        string   `json:"lang,omitempty"         validate:"omitempty,bcp47_language_tag,max=35"`
+	WebhookURLs []string `json:"webhook_urls,omitempty" validate:"max=10,dive,startswith=https://|startswith=http://,url,max=2000"`
+}
+
+type apiCreateWishListResponse struct {
+	ID      string `json:"id"`
+	AdminID string `json:"admin_id"`
+}
+
+type apiUpdateListElementsRequest struct {
+	Elements []apiElement `json:"elements" validate:"max=1000,dive"`
+}
+
+type apiAddWebhookRequest struct {
+	URL string `json:"url" validate:"required,startswith=https://|startswith=http://,url,max=2000"`
+}
+
+func (s Server) apiCreateWishList(w http.ResponseWriter, r *http.Request) {
+	var req apiCreateWishListRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+
+	params := wishlister.CreateWishlistParams{
+		Name:        req.Name,
+		Username:    req.Username,
+		UserEmail:   req.UserEmail,
+		Lang:        apiLanguage(req.Lang),
+		WebhookURLs: req.WebhookURLs,
+	}
+	if req.EventDate != "" {
+		// the format has already been validated
+		params.EventDate, _ = time.Parse(time.DateOnly, req.EventDate)
+	}
+
+	listID, adminID, err := s.wishlister.CreateWishList(r.Context(), params)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, apiCreateWishListResponse{
+		ID:      listID,
+		AdminID: adminID,
+	})
+}
+
+func (s Server) apiGetWishList(w http.ResponseWriter, r *http.Request) {
+	list, err := s.wishlister.GetWishList(r.Context(), chi.URLParam(r, "listID"))
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, newAPIWishList(list))
+}
+
+func (s Server) apiGetEditableWishList(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+
+	list, err := s.wishlister.GetEditableWishList(r.Context(), params.ListID, params.AdminID)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, newAPIWishList(list))
+}
+
+func (s Server) apiUpdateListElements(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+
+	var req apiUpdateListElementsRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+
+	elements := make([]wishlister.WishListElement, len(req.Elements))
+	for idx, element := range req.Elements {
+		elements[idx] = wishlister.WishListElement{
+			Name:        element.Name,
+			Description: element.Description,
+			URL:         element.URL,
+		}
+	}
+
+	err := s.wishlister.UpdateListElements(r.Context(), params.ListID, params.AdminID, elements)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s Server) apiGetWishListChanges(w http.ResponseWriter, r *http.Request) {
+	changes, err := s.wishlister.GetWishListChanges(r.Context(), chi.URLParam(r, "listID"))
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	resp := make([]apiWishListChange, 0, len(changes))
+	for _, change := range changes {
+		resp = append(resp, apiWishListChange{
+			ID:      change.ID,
+			Kind:    string(change.Kind),
+			Element: newAPIElement(change.Element),
+			Time:    change.Time,
+		})
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s Server) apiGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+
+	webhooks, err := s.wishlister.GetWebhooks(r.Context(), params.ListID, params.AdminID)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	resp := make([]apiWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		resp = append(resp, newAPIWebhook(webhook))
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s Server) apiAddWebhook(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+
+	var req apiAddWebhookRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+
+	webhook, err := s.wishlister.AddWebhook(r.Context(), params.ListID, params.AdminID, req.URL)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, newAPIWebhook(webhook))
+}
+
+func (s Server) apiGetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+
+	deliveries, err := s.wishlister.GetWebhookDeliveries(
+		r.Context(),
+		params.ListID,
+		params.AdminID,
+	)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	resp := make([]apiWebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, apiWebhookDelivery{
+			ID:         delivery.ID,
+			WebhookID:  delivery.WebhookID,
+			URL:        delivery.URL,
+			Event:      string(delivery.Event),
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Error:      delivery.Error,
+			Time:       delivery.Time,
+		})
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s Server) apiDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+
+	err := s.wishlister.DeleteWebhook(
+		r.Context(),
+		params.ListID,
+		params.AdminID,
+		chi.URLParam(r, "webhookID"),
+	)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newAPIWishList(list wishlister.WishList) apiWishList {
+	resp := apiWishList{
+		ID:       list.ID,
+		AdminID:  list.AdminID,
+		Name:     list.Name,
+		GroupID:  list.GroupID,
+		Username: list.Username,
+		Elements: make([]apiElement, 0, len(list.Elements)),
+	}
+
+	if !list.EventDate.IsZero() {
+		resp.EventDate = list.EventDate.Format(time.DateOnly)
+	}
+
+	for _, element := range list.Elements {
+		resp.Elements = append(resp.Elements, newAPIElement(element))
+	}
+
+	return resp
+}
+
+func newAPIElement(element wishlister.WishListElement) apiElement {
+	return apiElement{
+		Name:        element.Name,
+		Description: element.Description,
+		URL:         element.URL,
+	}
+}
+
+func newAPIWebhook(webhook wishlister.Webhook) apiWebhook {
+	return apiWebhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+type apiSession struct {
+	SessionID string `json:"session_id,omitempty"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username,omitempty"`
+	UserEmail string `json:"user_email,omitempty"`
+	ReadOnly  bool   `json:"read_only"`
+	// ExpiresAt is nil for the sessions opened with an API token, as they do not
+	// expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type apiCreateGroupRequest struct {
+	Name      string `json:"name"       validate:"required,max=255"`
+	UserEmail string `json:"user_email" validate:"required,email,max=255"`
+}
+
+type apiCreateGroupResponse struct {
+	ID string `json:"id"`
+}
+
+type apiSendMagicLinkRequest struct {
+	Email string `json:"email"          validate:"required,email,max=255"`
+	Lang  string `json:"lang,omitempty" validate:"omitempty,bcp47_language_tag,max=35"`
+}
+
+type apiCreateSessionRequest struct {
+	MagicLinkToken string `json:"magic_link_token" validate:"required"`
+}
+
+type apiCalendarToken struct {
+	Token string `json:"token"`
+}
+
+type apiToken struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ReadOnly  bool      `json:"read_only"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type apiCreateTokenRequest struct {
+	Name     string `json:"name"      validate:"required,max=255"`
+	ReadOnly bool   `json:"read_only"`
+}
+
+type apiCreatedToken struct {
+	apiToken
+
+	// Token is the value of the token, only returned when it is created.
+	Token string `json:"token"`
+}
+
+func (s Server) apiCreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req apiCreateGroupRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+
+	groupID, err := s.wishlister.CreateGroup(r.Context(), wishlister.CreateGroupParams{
+		Name:      req.Name,
+		UserEmail: req.UserEmail,
+	})
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, apiCreateGroupResponse{ID: groupID})
+}
+
+func (s Server) apiSendMagicLink(w http.ResponseWriter, r *http.Request) {
+	var req apiSendMagicLinkRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+
+	err := s.wishlister.SendMagicLink(r.Context(), req.Email, apiLanguage(req.Lang))
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// apiCreateSession exchanges a magic link token for a session.
+func (s Server) apiCreateSession(w http.ResponseWriter, r *http.Request) {
+	var req apiCreateSessionRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+
+	session, err := s.wishlister.GetSessionByMagicLink(r.Context(), req.MagicLinkToken)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, newAPISession(session))
+}
+
+func (s Server) apiGetSession(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getAPISession(r)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, newAPISession(session))
+}
+
+func (s Server) apiDeleteSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || sessionID == "" {
+		s.writeAPIError(w, ErrAPIUnauthorized)
+		return
+	}
+
+	if strings.HasPrefix(sessionID, wishlister.APITokenPrefix) {
+		s.writeAPIErrorCode(
+			w,
+			http.StatusBadRequest,
+			"api_token",
+			"API tokens cannot be deleted as sessions, revoke them instead",
+		)
+		return
+	}
+
+	s.wishlister.DeleteSession(r.Context(), sessionID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s Server) apiGetUserWishLists(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getAPIUserSession(r)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	lists, err := s.wishlister.GetUserWishLists(r.Context(), session.UserID)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	resp := newAPIWishLists(lists)
+	if session.ReadOnly {
+		// The admin IDs would allow to edit the lists.
+		for idx := range resp {
+			resp[idx].AdminID = ""
+		}
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s Server) apiGetUserCalendarToken(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getAPIUserSession(r)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	token, err := s.wishlister.GetUserCalendarToken(r.Context(), session.UserID)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, apiCalendarToken{Token: token})
+}
+
+func (s Server) apiResetUserCalendarToken(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getAPIUserSession(r)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	token, err := s.wishlister.ResetUserCalendarToken(r.Context(), session.UserID)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, apiCalendarToken{Token: token})
+}
+
+func (s Server) apiGetAPITokens(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getAPIUserSession(r)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	tokens, err := s.wishlister.GetAPITokens(r.Context(), session.UserID)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	resp := make([]apiToken, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, newAPIToken(token))
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+func (s Server) apiCreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getAPIUserSession(r)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	var req apiCreateTokenRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+
+	token, value, err := s.wishlister.CreateAPIToken(
+		r.Context(),
+		session.UserID,
+		req.Name,
+		req.ReadOnly,
+	)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, apiCreatedToken{
+		apiToken: newAPIToken(token),
+		Token:    value,
+	})
+}
+
+func (s Server) apiDeleteAPIToken(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getAPIUserSession(r)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	err = s.wishlister.DeleteAPIToken(r.Context(), session.UserID, chi.URLParam(r, "tokenID"))
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s Server) apiGetCalendarWishLists(w http.ResponseWriter, r *http.Request) {
+	lists, err := s.wishlister.GetCalendarWishLists(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, newAPIWishLists(lists))
+}
+
+func newAPISession(session wishlister.Session) apiSession {
+	resp := apiSession{
+		SessionID: session.SessionID,
+		UserID:    session.UserID,
+		Username:  session.Username,
+		UserEmail: session.UserEmail,
+		ReadOnly:  session.ReadOnly,
+	}
+	if !session.ExpiresAt.IsZero() {
+		resp.ExpiresAt = &session.ExpiresAt
+	}
+
+	return resp
+}
+
+func newAPIToken(token wishlister.APIToken) apiToken {
+	return apiToken{
+		ID:        token.ID,
+		Name:      token.Name,
+		ReadOnly:  token.ReadOnly,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+func newAPIWishLists(lists []wishlister.WishList) []apiWishList {
+	resp := make([]apiWishList, 0, len(lists))
+	for _, list := range lists {
+		apiList := newAPIWishList(list)
+		// elements are not loaded for lists of lists
+		apiList.Elements = nil
+		resp = append(resp, apiList)
+	}
+
+	return resp
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+)
+
+// magicLinkSender keeps the magic link tokens instead of sending them.
+type magicLinkSender struct {
+	email.NoMailer
+
+	tokens chan string
+}
+
+func (s magicLinkSender) SendMagicLink(
+	_ context.Context,
+	_ string,
+	_ language.Tag,
+	token string,
+) error {
+	s.tokens <- token
+	return nil
+}
+
+func TestAPICreateTokenBlankName(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		sender := magicLinkSender{tokens: make(chan string, 1)}
+		app, err := wishlister.NewWithConfig(wishlister.Config{
+			DB:          db,
+			Engine:      engine,
+			EmailSender: sender,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = app.SendMagicLink(ctx, "alice@example.com", language.English)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		session, err := app.GetSessionByMagicLink(ctx, <-sender.tokens)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := New(Config{Wishlister: app, BaseURL: "https://example.org"})
+		r := httptest.NewRequest(
+			http.MethodPost,
+			"/api/v1/users/"+session.UserID+"/tokens",
+			strings.NewReader(`{"name": "  "}`),
+		)
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", "Bearer "+session.SessionID)
+		w := httptest.NewRecorder()
+
+		s.ServeHTTP(w, r)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("got %d, expected %d: %s", w.Code, http.StatusUnprocessableEntity, w.Body)
+		}
+
+		var body struct {
+			Error apiErrorBody `json:"error"`
+		}
+		err = json.NewDecoder(w.Body).Decode(&body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if body.Error.Code != "validation_error" ||
+			body.Error.Fields["name"] != requiredFieldMessage {
+			t.Errorf("got error %+v", body.Error)
+		}
+	})
+}
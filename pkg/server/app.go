@@ -3,21 +3,31 @@ package server
 
 import (
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"reflect"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-playground/validator/v10"
 
 	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/urls"
 )
 
 // Server expose a single method Run() to run the web server.
 type Server struct {
+	addr       string
+	branding   branding.Branding
+	devMode    bool
 	logger     slog.Logger
+	openAPI    openAPIDocument
 	router     chi.Router
 	templates  Templates
+	urls       urls.Builder
 	validate   *validator.Validate
 	wishlister wishlister.App
 }
@@ -25,6 +35,20 @@ type Server struct {
 // Config is the server configuration.
 type Config struct {
 	Wishlister wishlister.App
+	// Addr is the TCP address to listen on, like ":3000".
+	Addr string
+	// BaseURL is the public URL of the server, like "https://example.org", used to
+	// build absolute links.
+	BaseURL string
+	// Branding is the identity of the site shown in the pages.
+	Branding branding.Branding
+	// DevMode enables the pages useful to develop the application, like the previews of
+	// the emails at /dev/emails.
+	DevMode bool
+	// TrustProxy takes the client IP address from the headers set by a reverse proxy,
+	// like X-Forwarded-For. It must only be set behind a proxy setting them, else any
+	// client can choose its address.
+	TrustProxy bool
 }
 
 // New creates a new Server object.
@@ -32,15 +56,33 @@ func New(config Config) Server {
 	templates := NewTemplates()
 
 	router := chi.NewRouter()
+	if config.TrustProxy {
+		router.Use(middleware.RealIP)
+	}
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
+	router.Use(withClientIP)
 
 	validate := validator.New(validator.WithRequiredStructEnabled())
+	// The API returns the validation errors using the JSON names of the fields.
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
 
 	s := Server{
+		addr:       config.Addr,
+		branding:   config.Branding,
+		devMode:    config.DevMode,
 		logger:     *slog.New(slog.NewTextHandler(os.Stderr, nil)),
+		openAPI:    newOpenAPIDocument(),
 		router:     router,
 		templates:  templates,
+		urls:       urls.New(config.BaseURL),
 		validate:   validate,
 		wishlister: config.Wishlister,
 	}
@@ -51,9 +93,29 @@ func New(config Config) Server {
 	return s
 }
 
+// withClientIP gives the IP address of the client to the app, which uses it to rate
+// limit the emails.
+func withClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			// The address was set by the RealIP middleware, without a port.
+			ip = r.RemoteAddr
+		}
+
+		next.ServeHTTP(w, r.WithContext(wishlister.WithClientIP(r.Context(), ip)))
+	})
+}
+
+// ServeHTTP serves a request, so the server can be used as an http.Handler.
+func (s Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
 // Run starts the server.
 //
-// It blocks forever.
-func (s Server) Run() {
-	s.logger.Error("error while running server", "err", http.ListenAndServe(":3000", s.router))
+// It blocks until the server fails, and returns the error.
+func (s Server) Run() error {
+	s.logger.Info("listening", "addr", s.addr)
+	return http.ListenAndServe(s.addr, s.router)
 }
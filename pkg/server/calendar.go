@@ -0,0 +1,63 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/ical"
+)
+
+// getCalendar renders the iCalendar feed of a user.
+//
+// The feed is not protected by the session, as calendar apps cannot log in. Instead
+// the URL contains a secret token.
+func (s Server) getCalendar(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	lists, err := s.wishlister.GetCalendarWishLists(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, wishlister.ErrCalendarNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+
+		s.logger.Error("failed to get calendar wishlists", "err", err)
+		http.Error(w, s.printer(r).T("calendar.error"), http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = ical.WriteWishLists(&buf, lists, s.printer(r), s.branding.Name, s.urls.WishList)
+	if err != nil {
+		s.logger.Error("error while rendering calendar", "err", err)
+		http.Error(w, s.printer(r).T("calendar.error"), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	_, _ = buf.WriteTo(w)
+}
+
+// resetCalendar generates a new calendar URL for the current user.
+func (s Server) resetCalendar(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getSession(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	_, err = s.wishlister.ResetUserCalendarToken(r.Context(), session.UserID)
+	if err != nil {
+		s.logger.Error("failed to reset calendar token", "err", err)
+		s.renderOK(w, r, s.templates.RenderUserListsView, ParamsUserListsView{
+			Error: s.printer(r).T("userLists.calendar.error"),
+		})
+		return
+	}
+
+	http.Redirect(w, r, "/lists", http.StatusSeeOther)
+}
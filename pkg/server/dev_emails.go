@@ -0,0 +1,24 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/erdnaxeli/wishlister/pkg/email"
+)
+
+// getDevEmails renders all the emails with sample data, to work on them without
+// sending them.
+//
+// It is only available in development mode.
+func (s Server) getDevEmails(w http.ResponseWriter, r *http.Request) {
+	previews, err := email.RenderPreviews(r.Context(), s.urls, s.branding, s.language(r))
+	if err != nil {
+		s.logger.Error("error while rendering emails", "err", err)
+		s.renderOK(w, r, s.templates.RenderDevEmails, ParamsDevEmails{
+			Error: s.printer(r).T("devEmails.error", err),
+		})
+		return
+	}
+
+	s.renderOK(w, r, s.templates.RenderDevEmails, ParamsDevEmails{Emails: previews})
+}
@@ -1,7 +1,6 @@
 package server
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/erdnaxeli/wishlister"
@@ -24,7 +23,7 @@ func (s Server) createNewGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	groupID, err := s.wishlister.CreateGroup(
+	_, err = s.wishlister.CreateGroup(
 		r.Context(),
 		wishlister.CreateGroupParams{
 			Name:      form.Name,
@@ -36,5 +35,6 @@ func (s Server) createNewGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Redirect(w, r, fmt.Sprintf("/g/%s", groupID), http.StatusSeeOther)
+	// Groups have no page yet, so the creation is confirmed on the form.
+	s.renderOK(w, r, s.templates.RenderNewGroup, ParamsNewGroup{Created: form.Name})
 }
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+)
+
+// TestCreateGroup checks that the creation of a group is confirmed to the user.
+func TestCreateGroup(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		app, err := wishlister.NewWithConfig(wishlister.Config{
+			DB:          db,
+			Engine:      engine,
+			EmailSender: email.NoMailer{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := New(Config{Wishlister: app, BaseURL: "https://example.org"})
+		form := url.Values{"name": {"Family"}, "email": {"alice@example.com"}}
+		r := httptest.NewRequest(
+			http.MethodPost,
+			"/group/new",
+			strings.NewReader(form.Encode()),
+		)
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("Accept-Language", "en")
+		w := httptest.NewRecorder()
+
+		s.ServeHTTP(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("got %d, expected %d", w.Code, http.StatusOK)
+		}
+
+		if !strings.Contains(w.Body.String(), "The group Family has been created.") {
+			t.Errorf("the creation is not confirmed in\n%s", w.Body)
+		}
+	})
+}
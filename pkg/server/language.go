@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
+)
+
+// langCookie is the name of the cookie holding the language chosen by the user.
+const langCookie = "lang"
+
+// language returns the language of the request.
+//
+// The language chosen by the user has the priority over the Accept-Language header.
+func (s Server) language(r *http.Request) language.Tag {
+	var chosen string
+	cookie, err := r.Cookie(langCookie)
+	if err == nil {
+		chosen = cookie.Value
+	}
+
+	return i18n.Match(chosen, r.Header.Get("Accept-Language"))
+}
+
+// printer returns the printer for the language of the request.
+func (s Server) printer(r *http.Request) i18n.Printer {
+	return i18n.NewPrinter(s.language(r))
+}
+
+// apiLanguage returns the language given in an API request, already validated, or an
+// undefined language if none was given.
+func apiLanguage(lang string) language.Tag {
+	if lang == "" {
+		return language.Und
+	}
+
+	return language.Make(lang)
+}
+
+// setLanguage saves the language chosen by the user, and redirects them to the page
+// they come from.
+func (s Server) setLanguage(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.Match(r.PostFormValue("lang"))
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     langCookie,
+		Value:    lang.String(),
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, refererPath(r), http.StatusSeeOther)
+}
+
+// refererPath returns the path of the page the request comes from, or "/" if it is
+// unknown.
+//
+// Only the path and the query are kept, so the user cannot be redirected to another
+// site.
+func refererPath(r *http.Request) string {
+	referer, err := url.Parse(r.Referer())
+	if err != nil || !strings.HasPrefix(referer.Path, "/") ||
+		strings.HasPrefix(referer.Path, "//") {
+		return "/"
+	}
+
+	referer.Scheme = ""
+	referer.Opaque = ""
+	referer.User = nil
+	referer.Host = ""
+	referer.Fragment = ""
+
+	return referer.String()
+}
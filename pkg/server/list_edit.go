@@ -12,6 +12,7 @@ import (
 	nanoid "github.com/matoous/go-nanoid/v2"
 
 	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
 )
 
 type listEditTmplParams struct {
@@ -51,12 +52,12 @@ func (s Server) editList(w http.ResponseWriter, r *http.Request) {
 	)
 	if err != nil {
 		if errors.Is(err, wishlister.ErrWishListNotFound) {
-			s.render(w, http.StatusNotFound, s.templates.RenderListNotFound, nil)
+			s.render(w, r, http.StatusNotFound, s.templates.RenderListNotFound, nil)
 			return
 		}
 
 		if errors.Is(err, wishlister.ErrWishListInvalidAdminID) {
-			s.render(w, http.StatusForbidden, s.templates.RenderListAccessDenied, nil)
+			s.render(w, r, http.StatusForbidden, s.templates.RenderListAccessDenied, params)
 			return
 		}
 
@@ -99,7 +100,7 @@ func (s Server) editList(w http.ResponseWriter, r *http.Request) {
 		Data: string(dataJSON),
 	}
 
-	s.renderOK(w, s.templates.RenderListEdit, tmplParams)
+	s.renderOK(w, r, s.templates.RenderListEdit, tmplParams)
 }
 
 func (s Server) validateEditForm(
@@ -120,9 +121,10 @@ func (s Server) validateEditForm(
 		return data, false, err
 	}
 
+	printer := s.printer(r)
 	for i, element := range data.Elements {
 		element.ID = uuid.NewString()
-		element, ok = s.validateElement(element, ok)
+		element, ok = s.validateElement(printer, element, ok)
 		data.Elements[i] = element
 	}
 
@@ -163,27 +165,31 @@ func listToEditData(list wishlister.WishList) editListForm {
 	return data
 }
 
-func (s Server) validateElement(element editListFormElement, ok bool) (editListFormElement, bool) {
+func (s Server) validateElement(
+	printer i18n.Printer,
+	element editListFormElement,
+	ok bool,
+) (editListFormElement, bool) {
 	if element.Name == "" {
-		element.NameError = "Le nom ne peut pas être vide."
+		element.NameError = printer.T("listEdit.name.required")
 		ok = false
 	} else if utf8.RuneCountInString(element.Name) > 255 {
-		element.NameError = "Le nom ne peut pas dépasser 255 caractères."
+		element.NameError = printer.T("listEdit.name.max")
 		ok = false
 	}
 
 	if utf8.RuneCountInString(element.Description) > 500 {
-		element.DescriptionError = "La description ne peut pas dépasser 500 caractères."
+		element.DescriptionError = printer.T("listEdit.description.max")
 		ok = false
 	}
 
 	if element.URL != "" {
 		err := s.validate.Var(element.URL, "startswith=https://|startswith=http://,url")
 		if err != nil {
-			element.URLError = "L'URL n'est pas valide."
+			element.URLError = printer.T("listEdit.url.invalid")
 			ok = false
 		} else if utf8.RuneCountInString(element.URL) > 2000 {
-			element.URLError = "L'URL ne peut pas dépasser 2000 caractères."
+			element.URLError = printer.T("listEdit.url.max")
 			ok = false
 		}
 	}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/atom"
+)
+
+// getWishListFeed renders an Atom feed of the changes of a wishlist.
+//
+// It uses the same access rules than the public view of the list: anyone with the
+// share link can subscribe to the feed.
+func (s Server) getWishListFeed(w http.ResponseWriter, r *http.Request) {
+	listID := chi.URLParam(r, "listID")
+
+	list, err := s.wishlister.GetWishList(r.Context(), listID)
+	if err != nil {
+		if errors.Is(err, wishlister.ErrWishListNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+
+		panic(err)
+	}
+
+	changes, err := s.wishlister.GetWishListChanges(r.Context(), listID)
+	if err != nil {
+		panic(err)
+	}
+
+	var buf bytes.Buffer
+	err = atom.WriteWishListChanges(
+		&buf,
+		list,
+		changes,
+		s.printer(r),
+		s.urls.WishList(listID),
+		s.urls.WishListFeed(listID),
+	)
+	if err != nil {
+		s.logger.Error("error while rendering wishlist feed", "err", err)
+		http.Error(w, s.printer(r).T("feed.error"), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	_, _ = buf.WriteTo(w)
+}
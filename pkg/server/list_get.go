@@ -38,7 +38,12 @@ func (s Server) getWishList(w http.ResponseWriter, r *http.Request) {
 		list, err = s.wishlister.GetEditableWishList(r.Context(), params.ListID, params.AdminID)
 		if err != nil {
 			if errors.Is(err, wishlister.ErrWishListInvalidAdminID) {
-				http.Redirect(w, r, fmt.Sprintf("/%s", params.ListID), http.StatusMovedPermanently)
+				http.Redirect(
+					w,
+					r,
+					fmt.Sprintf("/l/%s", params.ListID),
+					http.StatusMovedPermanently,
+				)
 				return
 			}
 
@@ -46,5 +51,13 @@ func (s Server) getWishList(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	s.renderOK(w, s.templates.RenderListView, list)
+	viewParams := ParamsListView{
+		WishList: list,
+		ShareURL: s.urls.WishList(list.ID),
+	}
+	if list.AdminID != "" {
+		viewParams.AdminURL = s.urls.EditableWishList(list.ID, list.AdminID)
+	}
+
+	s.renderOK(w, r, s.templates.RenderListView, viewParams)
 }
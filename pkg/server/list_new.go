@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
@@ -11,9 +12,10 @@ import (
 )
 
 type createWishListForm struct {
-	Name  string `form:"name"  validate:"required,max=255"`
-	User  string `form:"user"  validate:"required,max=255"`
-	Email string `form:"email" validate:"omitempty,email,max=255"`
+	Name      string `form:"name"       validate:"required,max=255"`
+	User      string `form:"user"       validate:"required,max=255"`
+	Email     string `form:"email"      validate:"omitempty,email,max=255"`
+	EventDate string `form:"event_date" validate:"omitempty,datetime=2006-01-02"`
 }
 
 func (s Server) getNewWishList(w http.ResponseWriter, r *http.Request) {
@@ -28,19 +30,20 @@ func (s Server) getNewWishList(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	s.renderOK(w, s.templates.RenderNew, params)
+	s.renderOK(w, r, s.templates.RenderNew, params)
 }
 
 func (s Server) createNewWishList(w http.ResponseWriter, r *http.Request) {
 	form := createWishListForm{
-		Name:  r.PostFormValue("name"),
-		User:  r.PostFormValue("user"),
-		Email: r.PostFormValue("email"),
+		Name:      r.PostFormValue("name"),
+		User:      r.PostFormValue("user"),
+		Email:     r.PostFormValue("email"),
+		EventDate: r.PostFormValue("event_date"),
 	}
 
 	err := s.validate.Struct(form)
 	if err != nil {
-		s.handleNewWishListError(w, form, err)
+		s.handleNewWishListError(w, r, form, err)
 		return
 	}
 
@@ -48,6 +51,11 @@ func (s Server) createNewWishList(w http.ResponseWriter, r *http.Request) {
 		Name:      form.Name,
 		Username:  form.User,
 		UserEmail: form.Email,
+		Lang:      s.language(r),
+	}
+	if form.EventDate != "" {
+		// the format has already been validated
+		params.EventDate, _ = time.Parse(time.DateOnly, form.EventDate)
 	}
 
 	listID, adminID, err := s.wishlister.CreateWishList(
@@ -55,14 +63,26 @@ func (s Server) createNewWishList(w http.ResponseWriter, r *http.Request) {
 		params,
 	)
 	if err != nil {
+		if errors.Is(err, wishlister.ErrRateLimited) {
+			s.render(w, r, http.StatusTooManyRequests, s.templates.RenderNew, ParamsNew{
+				Error:     s.printer(r).T("new.rateLimited"),
+				Name:      form.Name,
+				User:      form.User,
+				Email:     form.Email,
+				EventDate: form.EventDate,
+			})
+			return
+		}
+
 		// We could get errors about empty fields here, but this should have been catched
 		// by the validation step before. So we just log and return a generic error.
 		s.logger.Error("failed to create new wish list: ", "err", err)
-		s.renderOK(w, s.templates.RenderNew, ParamsNew{
-			Error: "Erreur lors de la soumission du formulaire, veuillez réessayer",
-			Name:  form.Name,
-			User:  form.User,
-			Email: form.Email,
+		s.renderOK(w, r, s.templates.RenderNew, ParamsNew{
+			Error:     s.printer(r).T("common.formError"),
+			Name:      form.Name,
+			User:      form.User,
+			Email:     form.Email,
+			EventDate: form.EventDate,
 		})
 		return
 	}
@@ -72,125 +92,135 @@ func (s Server) createNewWishList(w http.ResponseWriter, r *http.Request) {
 
 func (s Server) handleNewWishListError(
 	w http.ResponseWriter,
+	r *http.Request,
 	form createWishListForm,
 	err error,
 ) {
 	formError := ParamsNew{
-		Name:  form.Name,
-		User:  form.User,
-		Email: form.Email,
+		Name:      form.Name,
+		User:      form.User,
+		Email:     form.Email,
+		EventDate: form.EventDate,
 	}
 
 	var invalidErr *validator.InvalidValidationError
 	if errors.As(err, &invalidErr) {
 		s.logger.Error("invalid validation error", "err", err)
 
-		formError.Error = "Erreur lors de la soumission du formulaire, veuillez réessayer."
-		s.renderOK(w, s.templates.RenderNew, formError)
+		formError.Error = s.printer(r).T("common.formError")
+		s.renderOK(w, r, s.templates.RenderNew, formError)
 		return
 	}
 
 	var validationErrors validator.ValidationErrors
 	if errors.As(err, &validationErrors) {
-		s.handleNewWishListValidationsErrors(w, formError, validationErrors)
+		s.handleNewWishListValidationsErrors(w, r, formError, validationErrors)
 		return
 	}
 
 	s.logger.Error("unknown error during form validation: ", "err", err)
-	formError.Error = "Erreur lors de la soumission du formulaire, veuillez réessayer."
-	s.renderOK(w, s.templates.RenderNew, formError)
+	formError.Error = s.printer(r).T("common.formError")
+	s.renderOK(w, r, s.templates.RenderNew, formError)
 }
 
 func (s Server) handleNewWishListValidationsErrors(
 	w http.ResponseWriter,
+	r *http.Request,
 	formError ParamsNew,
 	validationErrors validator.ValidationErrors,
 ) {
 	if len(validationErrors) == 0 {
 		// that should not happend
 		s.logger.Error("validation errors but length is 0")
-		formError.Error = "Erreur lors de la soumission du formulaire, veuillez réessayer."
-		s.renderOK(w, s.templates.RenderNew, formError)
+		formError.Error = s.printer(r).T("common.formError")
+		s.renderOK(w, r, s.templates.RenderNew, formError)
 		return
 	}
 
 	validationErr := validationErrors[0]
 	switch validationErr.Field() {
 	case "Name":
-		s.handleNewWishListNameError(w, formError, validationErr)
+		s.handleNewWishListNameError(w, r, formError, validationErr)
 		return
 	case "User":
-		s.handleNewWishListUserError(w, formError, validationErr)
+		s.handleNewWishListUserError(w, r, formError, validationErr)
 		return
 	case "Email":
-		s.handleNewWishListEmailError(w, formError, validationErr)
+		s.handleNewWishListEmailError(w, r, formError, validationErr)
+		return
+	case "EventDate":
+		formError.EventDateError = s.printer(r).T("new.eventDate.invalid")
+		s.renderOK(w, r, s.templates.RenderNew, formError)
 		return
 	default:
 		s.logger.Error("unknown validation error field", "field", validationErr.Field())
-		s.renderOK(w, s.templates.RenderNew, formError)
+		s.renderOK(w, r, s.templates.RenderNew, formError)
 		return
 	}
 }
 
 func (s Server) handleNewWishListNameError(
 	w http.ResponseWriter,
+	r *http.Request,
 	formError ParamsNew,
 	validationErr validator.FieldError,
 ) {
 	switch validationErr.Tag() {
 	case "required":
-		formError.NameError = "Le nom est requis."
+		formError.NameError = s.printer(r).T("new.name.required")
 	case "max":
-		formError.NameError = "Le nom doit faire moins de 255 caractères."
+		formError.NameError = s.printer(r).T("new.name.max")
 	default:
 		s.logger.Error(
 			"unknown validation error tag on name field",
 			"tag", validationErr.Tag(),
 		)
-		formError.NameError = "Le nom est requis et doit faire moins de 255 caractères."
+		formError.NameError = s.printer(r).T("new.name.invalid")
 	}
 
-	s.renderOK(w, s.templates.RenderNew, formError)
+	s.renderOK(w, r, s.templates.RenderNew, formError)
 }
 
 func (s Server) handleNewWishListUserError(
 	w http.ResponseWriter,
+	r *http.Request,
 	formError ParamsNew,
 	validationErr validator.FieldError,
 ) {
 	switch validationErr.Tag() {
 	case "required":
-		formError.UserError = "Le nom d'utilisateur est requis."
+		formError.UserError = s.printer(r).T("new.user.required")
 	case "max":
-		formError.UserError = "Le nom d'utilisateur doit faire moins de 255 caractères."
+		formError.UserError = s.printer(r).T("new.user.max")
 	default:
 		s.logger.Error(
 			"unknown validation error tag on user field",
 			"tag", validationErr.Tag(),
 		)
-		formError.UserError = "Le nom d'utilisateur est requis et doit faire moins de 255 caractères."
+		formError.UserError = s.printer(r).T("new.user.invalid")
 	}
 
-	s.renderOK(w, s.templates.RenderNew, formError)
+	s.renderOK(w, r, s.templates.RenderNew, formError)
 }
 
 func (s Server) handleNewWishListEmailError(
 	w http.ResponseWriter,
+	r *http.Request,
 	formError ParamsNew,
 	validationErr validator.FieldError,
 ) {
 	switch validationErr.Tag() {
 	case "email":
-		formError.EmailError = "L'adresse email n'est pas valide."
+		formError.EmailError = s.printer(r).T("new.email.invalid")
 	case "max":
-		formError.EmailError = "L'adresse email doit faire moins de 255 caractères."
+		formError.EmailError = s.printer(r).T("new.email.max")
 	default:
 		s.logger.Error(
 			"unknown validation error tag on email field: ",
 			"tag", validationErr.Tag(),
 		)
-		formError.EmailError = "L'adresse email est requise et doit faire moins de 255 caractères."
+		formError.EmailError = s.printer(r).T("new.email.error")
 	}
 
-	s.renderOK(w, s.templates.RenderNew, formError)
+	s.renderOK(w, r, s.templates.RenderNew, formError)
 }
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/pdf"
+)
+
+// printWishList renders a wishlist as a PDF document.
+//
+// It uses the same access rules than the public view of the list: anyone with the
+// share link can print it.
+func (s Server) printWishList(w http.ResponseWriter, r *http.Request) {
+	listID := chi.URLParam(r, "listID")
+
+	list, err := s.wishlister.GetWishList(r.Context(), listID)
+	if err != nil {
+		if errors.Is(err, wishlister.ErrWishListNotFound) {
+			s.render(w, r, http.StatusNotFound, s.templates.RenderListNotFound, nil)
+			return
+		}
+
+		panic(err)
+	}
+
+	// We render in a buffer first, so we can still return an error if the rendering
+	// fails.
+	var buf bytes.Buffer
+	err = pdf.WriteWishList(&buf, list, s.printer(r), s.branding.Name)
+	if err != nil {
+		s.logger.Error("error while rendering wishlist as PDF", "err", err)
+		http.Error(w, s.printer(r).T("print.error"), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf("inline; filename=\"%s.pdf\"", list.ID),
+	)
+	_, _ = buf.WriteTo(w)
+}
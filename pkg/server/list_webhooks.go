@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+type addWebhookForm struct {
+	URL string `form:"url" validate:"required,startswith=https://|startswith=http://,url,max=2000"`
+}
+
+func (s Server) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.renderWebhooks(w, r, ParamsListWebhooks{})
+}
+
+func (s Server) addWebhook(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+	form := addWebhookForm{
+		URL: r.PostFormValue("url"),
+	}
+
+	err := s.validate.Struct(form)
+	if err != nil {
+		s.renderWebhooks(w, r, ParamsListWebhooks{
+			URL:      form.URL,
+			URLError: s.printer(r).T("listWebhooks.url.invalid"),
+		})
+		return
+	}
+
+	_, err = s.wishlister.AddWebhook(r.Context(), params.ListID, params.AdminID, form.URL)
+	if err != nil {
+		if errors.Is(err, wishlister.ErrWebhookInvalidURL) {
+			s.renderWebhooks(w, r, ParamsListWebhooks{
+				URL:      form.URL,
+				URLError: s.printer(r).T("listWebhooks.url.invalid"),
+			})
+			return
+		}
+
+		s.handleListAdminError(w, r, err)
+		return
+	}
+
+	http.Redirect(
+		w, r,
+		fmt.Sprintf("/l/%s/%s/webhooks", params.ListID, params.AdminID),
+		http.StatusSeeOther,
+	)
+}
+
+func (s Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	params := readWishListParam(r)
+	webhookID := chi.URLParam(r, "webhookID")
+
+	err := s.wishlister.DeleteWebhook(r.Context(), params.ListID, params.AdminID, webhookID)
+	if err != nil && !errors.Is(err, wishlister.ErrWebhookNotFound) {
+		s.handleListAdminError(w, r, err)
+		return
+	}
+
+	http.Redirect(
+		w, r,
+		fmt.Sprintf("/l/%s/%s/webhooks", params.ListID, params.AdminID),
+		http.StatusSeeOther,
+	)
+}
+
+// renderWebhooks renders the webhooks page of a list.
+//
+// The given params are completed with the list, its webhooks and their deliveries.
+func (s Server) renderWebhooks(
+	w http.ResponseWriter,
+	r *http.Request,
+	tmplParams ParamsListWebhooks,
+) {
+	params := readWishListParam(r)
+
+	list, err := s.wishlister.GetEditableWishList(r.Context(), params.ListID, params.AdminID)
+	if err != nil {
+		s.handleListAdminError(w, r, err)
+		return
+	}
+
+	webhooks, err := s.wishlister.GetWebhooks(r.Context(), params.ListID, params.AdminID)
+	if err != nil {
+		s.handleListAdminError(w, r, err)
+		return
+	}
+
+	deliveries, err := s.wishlister.GetWebhookDeliveries(r.Context(), params.ListID, params.AdminID)
+	if err != nil {
+		s.handleListAdminError(w, r, err)
+		return
+	}
+
+	tmplParams.ID = list.ID
+	tmplParams.AdminID = list.AdminID
+	tmplParams.Name = list.Name
+	tmplParams.Webhooks = webhooks
+	tmplParams.Deliveries = deliveries
+
+	s.renderOK(w, r, s.templates.RenderListWebhooks, tmplParams)
+}
+
+// handleListAdminError renders the error page corresponding to an error returned when
+// accessing a list with an admin ID.
+func (s Server) handleListAdminError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, wishlister.ErrWishListNotFound) {
+		s.render(w, r, http.StatusNotFound, s.templates.RenderListNotFound, nil)
+		return
+	}
+
+	if errors.Is(err, wishlister.ErrWishListInvalidAdminID) {
+		s.render(
+			w, r, http.StatusForbidden, s.templates.RenderListAccessDenied, readWishListParam(r),
+		)
+		return
+	}
+
+	panic(err)
+}
@@ -0,0 +1,712 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// apiPrefix is the path prefix of all the API v1 endpoints.
+const apiPrefix = "/api/v1"
+
+// openAPIDocument is an OpenAPI 3 document.
+//
+// Only the parts of the specification used to describe our API are implemented.
+type openAPIDocument struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       openAPIInfo                            `json:"info"`
+	Servers    []openAPIServer                        `json:"servers"`
+	Paths      map[string]map[string]openAPIOperation `json:"paths"`
+	Components openAPIComponents                      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required"`
+	Schema      openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                   `json:"$ref,omitempty"`
+	Type                 string                   `json:"type,omitempty"`
+	Format               string                   `json:"format,omitempty"`
+	Description          string                   `json:"description,omitempty"`
+	Enum                 []string                 `json:"enum,omitempty"`
+	MaxLength            int                      `json:"maxLength,omitempty"`
+	MaxItems             int                      `json:"maxItems,omitempty"`
+	Required             []string                 `json:"required,omitempty"`
+	Properties           map[string]openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema           `json:"additionalProperties,omitempty"`
+	Items                *openAPISchema           `json:"items,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]openAPISchema         `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme"`
+	Description string `json:"description,omitempty"`
+}
+
+func (s Server) getOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	s.writeJSON(w, http.StatusOK, s.openAPI)
+}
+
+// newOpenAPIDocument returns the OpenAPI document describing the API.
+func newOpenAPIDocument() openAPIDocument {
+	listParams := []openAPIParameter{
+		pathParam("listID", "The ID of the wishlist."),
+	}
+	adminParams := []openAPIParameter{
+		pathParam("listID", "The ID of the wishlist."),
+		pathParam("adminID", "The admin ID of the wishlist, needed to edit it."),
+	}
+	userParams := []openAPIParameter{
+		pathParam("userID", "The ID of the user, must match the session."),
+	}
+
+	return openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       "Wishlister API",
+			Description: "Create, read and edit wishlists.",
+			Version:     "1",
+		},
+		Servers: []openAPIServer{{URL: apiPrefix}},
+		Paths: map[string]map[string]openAPIOperation{
+			"/lists": {
+				"post": {
+					OperationID: "createWishList",
+					Summary:     "Create a new wishlist.",
+					Tags:        []string{"lists"},
+					RequestBody: jsonBody(schemaRef("CreateWishListRequest")),
+					Responses: responses(
+						http.StatusCreated,
+						jsonResponse("The wishlist was created.", schemaRef("CreatedWishList")),
+						http.StatusBadRequest,
+						http.StatusUnprocessableEntity,
+						http.StatusTooManyRequests,
+					),
+				},
+			},
+			"/lists/{listID}": {
+				"get": {
+					OperationID: "getWishList",
+					Summary:     "Get a wishlist with its elements.",
+					Tags:        []string{"lists"},
+					Parameters:  listParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The wishlist.", schemaRef("WishList")),
+						http.StatusNotFound,
+					),
+				},
+			},
+			"/lists/{listID}/changes": {
+				"get": {
+					OperationID: "getWishListChanges",
+					Summary:     "Get the last changes made on the elements of a wishlist.",
+					Tags:        []string{"lists"},
+					Parameters:  listParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The changes, most recent first.", arrayOf("WishListChange")),
+						http.StatusNotFound,
+					),
+				},
+			},
+			"/lists/{listID}/{adminID}": {
+				"get": {
+					OperationID: "getEditableWishList",
+					Summary:     "Get a wishlist with its elements, checking its admin ID.",
+					Tags:        []string{"lists"},
+					Parameters:  adminParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The wishlist.", schemaRef("WishList")),
+						http.StatusForbidden,
+						http.StatusNotFound,
+					),
+				},
+			},
+			"/lists/{listID}/{adminID}/elements": {
+				"put": {
+					OperationID: "updateListElements",
+					Summary:     "Replace the elements of a wishlist.",
+					Tags:        []string{"lists"},
+					Parameters:  adminParams,
+					RequestBody: jsonBody(schemaRef("UpdateListElementsRequest")),
+					Responses: responses(
+						http.StatusNoContent,
+						openAPIResponse{Description: "The elements were updated."},
+						http.StatusBadRequest,
+						http.StatusForbidden,
+						http.StatusNotFound,
+						http.StatusUnprocessableEntity,
+					),
+				},
+			},
+			"/lists/{listID}/{adminID}/webhooks": {
+				"get": {
+					OperationID: "getWebhooks",
+					Summary:     "Get the webhooks of a wishlist.",
+					Tags:        []string{"webhooks"},
+					Parameters:  adminParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The webhooks.", arrayOf("Webhook")),
+						http.StatusForbidden,
+						http.StatusNotFound,
+					),
+				},
+				"post": {
+					OperationID: "addWebhook",
+					Summary:     "Add a webhook to a wishlist.",
+					Tags:        []string{"webhooks"},
+					Parameters:  adminParams,
+					RequestBody: jsonBody(schemaRef("AddWebhookRequest")),
+					Responses: responses(
+						http.StatusCreated,
+						jsonResponse("The webhook was created.", schemaRef("Webhook")),
+						http.StatusBadRequest,
+						http.StatusForbidden,
+						http.StatusNotFound,
+						http.StatusUnprocessableEntity,
+					),
+				},
+			},
+			"/lists/{listID}/{adminID}/webhooks/deliveries": {
+				"get": {
+					OperationID: "getWebhookDeliveries",
+					Summary:     "Get the last deliveries of the webhooks of a wishlist.",
+					Tags:        []string{"webhooks"},
+					Parameters:  adminParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse(
+							"The deliveries, most recent first.",
+							arrayOf("WebhookDelivery"),
+						),
+						http.StatusForbidden,
+						http.StatusNotFound,
+					),
+				},
+			},
+			"/lists/{listID}/{adminID}/webhooks/{webhookID}": {
+				"delete": {
+					OperationID: "deleteWebhook",
+					Summary:     "Delete a webhook of a wishlist.",
+					Tags:        []string{"webhooks"},
+					Parameters: append(
+						adminParams,
+						pathParam("webhookID", "The ID of the webhook."),
+					),
+					Responses: responses(
+						http.StatusNoContent,
+						openAPIResponse{Description: "The webhook was deleted."},
+						http.StatusForbidden,
+						http.StatusNotFound,
+					),
+				},
+			},
+			"/groups": {
+				"post": {
+					OperationID: "createGroup",
+					Summary:     "Create a new group.",
+					Tags:        []string{"groups"},
+					RequestBody: jsonBody(schemaRef("CreateGroupRequest")),
+					Responses: responses(
+						http.StatusCreated,
+						jsonResponse("The group was created.", schemaRef("CreatedGroup")),
+						http.StatusBadRequest,
+						http.StatusUnprocessableEntity,
+					),
+				},
+			},
+			"/magic-links": {
+				"post": {
+					OperationID: "sendMagicLink",
+					Summary:     "Send a magic link by email to log in.",
+					Tags:        []string{"sessions"},
+					RequestBody: jsonBody(schemaRef("SendMagicLinkRequest")),
+					Responses: responses(
+						http.StatusAccepted,
+						openAPIResponse{Description: "The magic link was sent."},
+						http.StatusBadRequest,
+						http.StatusUnprocessableEntity,
+						http.StatusTooManyRequests,
+					),
+				},
+			},
+			"/sessions": {
+				"post": {
+					OperationID: "createSession",
+					Summary:     "Exchange a magic link token for a session.",
+					Tags:        []string{"sessions"},
+					RequestBody: jsonBody(schemaRef("CreateSessionRequest")),
+					Responses: responses(
+						http.StatusCreated,
+						jsonResponse("The session was created.", schemaRef("Session")),
+						http.StatusBadRequest,
+						http.StatusUnauthorized,
+						http.StatusUnprocessableEntity,
+					),
+				},
+			},
+			"/session": {
+				"get": {
+					OperationID: "getSession",
+					Summary:     "Get the current session.",
+					Tags:        []string{"sessions"},
+					Security:    bearerSecurity(),
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The session.", schemaRef("Session")),
+						http.StatusUnauthorized,
+					),
+				},
+				"delete": {
+					OperationID: "deleteSession",
+					Summary:     "Delete the current session.",
+					Tags:        []string{"sessions"},
+					Security:    bearerSecurity(),
+					Responses: responses(
+						http.StatusNoContent,
+						openAPIResponse{Description: "The session was deleted."},
+						http.StatusBadRequest,
+						http.StatusUnauthorized,
+					),
+				},
+			},
+			"/users/{userID}/lists": {
+				"get": {
+					OperationID: "getUserWishLists",
+					Summary:     "Get the wishlists of a user, without their elements.",
+					Tags:        []string{"users"},
+					Security:    bearerSecurity(),
+					Parameters:  userParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The wishlists.", arrayOf("WishList")),
+						http.StatusUnauthorized,
+						http.StatusForbidden,
+					),
+				},
+			},
+			"/users/{userID}/calendar-token": {
+				"get": {
+					OperationID: "getUserCalendarToken",
+					Summary:     "Get the calendar token of a user, empty if none was created.",
+					Tags:        []string{"users"},
+					Security:    bearerSecurity(),
+					Parameters:  userParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The calendar token.", schemaRef("CalendarToken")),
+						http.StatusUnauthorized,
+						http.StatusForbidden,
+					),
+				},
+				"post": {
+					OperationID: "resetUserCalendarToken",
+					Summary:     "Replace the calendar token of a user by a new one.",
+					Tags:        []string{"users"},
+					Security:    bearerSecurity(),
+					Parameters:  userParams,
+					Responses: responses(
+						http.StatusCreated,
+						jsonResponse("The new calendar token.", schemaRef("CalendarToken")),
+						http.StatusUnauthorized,
+						http.StatusForbidden,
+					),
+				},
+			},
+			"/users/{userID}/tokens": {
+				"get": {
+					OperationID: "getAPITokens",
+					Summary:     "Get the API tokens of a user.",
+					Tags:        []string{"users"},
+					Security:    bearerSecurity(),
+					Parameters:  userParams,
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The API tokens, without their value.", arrayOf("APIToken")),
+						http.StatusUnauthorized,
+						http.StatusForbidden,
+					),
+				},
+				"post": {
+					OperationID: "createAPIToken",
+					Summary:     "Create a new API token for a user.",
+					Tags:        []string{"users"},
+					Security:    bearerSecurity(),
+					Parameters:  userParams,
+					RequestBody: jsonBody(schemaRef("CreateAPITokenRequest")),
+					Responses: responses(
+						http.StatusCreated,
+						jsonResponse(
+							"The API token, with its value which cannot be retrieved later.",
+							schemaRef("CreatedAPIToken"),
+						),
+						http.StatusBadRequest,
+						http.StatusUnauthorized,
+						http.StatusForbidden,
+						http.StatusUnprocessableEntity,
+					),
+				},
+			},
+			"/users/{userID}/tokens/{tokenID}": {
+				"delete": {
+					OperationID: "deleteAPIToken",
+					Summary:     "Revoke an API token of a user.",
+					Tags:        []string{"users"},
+					Security:    bearerSecurity(),
+					Parameters: append(
+						userParams,
+						pathParam("tokenID", "The ID of the API token."),
+					),
+					Responses: responses(
+						http.StatusNoContent,
+						openAPIResponse{Description: "The API token was revoked."},
+						http.StatusUnauthorized,
+						http.StatusForbidden,
+						http.StatusNotFound,
+					),
+				},
+			},
+			"/calendars/{token}/lists": {
+				"get": {
+					OperationID: "getCalendarWishLists",
+					Summary:     "Get the wishlists with an event date of a calendar.",
+					Tags:        []string{"users"},
+					Parameters: []openAPIParameter{
+						pathParam("token", "The calendar token."),
+					},
+					Responses: responses(
+						http.StatusOK,
+						jsonResponse("The wishlists.", arrayOf("WishList")),
+						http.StatusNotFound,
+					),
+				},
+			},
+		},
+		Components: openAPIComponents{
+			Schemas: openAPISchemas(),
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"session": {
+					Type:   "http",
+					Scheme: "bearer",
+					Description: "A session ID, as returned by createSession, or a personal " +
+						"API token, as returned by createAPIToken. Read-only tokens are " +
+						"refused for methods other than GET.",
+				},
+			},
+		},
+	}
+}
+
+func openAPISchemas() map[string]openAPISchema {
+	str := openAPISchema{Type: "string"}
+	date := openAPISchema{Type: "string", Format: "date"}
+	dateTime := openAPISchema{Type: "string", Format: "date-time"}
+	email := openAPISchema{Type: "string", Format: "email", MaxLength: 255}
+	name := openAPISchema{Type: "string", MaxLength: 255}
+	url := openAPISchema{Type: "string", Format: "uri", MaxLength: 2000}
+	lang := openAPISchema{
+		Type:        "string",
+		MaxLength:   35,
+		Description: "Language of the emails, like \"en\". The known one is kept if not set.",
+	}
+
+	return map[string]openAPISchema{
+		"Error": {
+			Type:     "object",
+			Required: []string{"error"},
+			Properties: map[string]openAPISchema{
+				"error": {
+					Type:     "object",
+					Required: []string{"code", "message"},
+					Properties: map[string]openAPISchema{
+						"code": {
+							Type:        "string",
+							Description: "A stable identifier of the error.",
+						},
+						"message": {Type: "string", Description: "A human readable description."},
+						"fields": {
+							Type:                 "object",
+							Description:          "The validation error of each invalid field.",
+							AdditionalProperties: &str,
+						},
+					},
+				},
+			},
+		},
+		"WishList": {
+			Type:     "object",
+			Required: []string{"id", "name"},
+			Properties: map[string]openAPISchema{
+				"id":         str,
+				"admin_id":   {Type: "string", Description: "Only set when the list is editable."},
+				"name":       str,
+				"group_id":   str,
+				"username":   str,
+				"event_date": date,
+				"elements": {
+					Type:        "array",
+					Description: "Not set when listing the wishlists of a user or calendar.",
+					Items:       &openAPISchema{Ref: "#/components/schemas/WishListElement"},
+				},
+			},
+		},
+		"WishListElement": {
+			Type:     "object",
+			Required: []string{"name"},
+			Properties: map[string]openAPISchema{
+				"name":        name,
+				"description": {Type: "string", MaxLength: 500},
+				"url":         url,
+			},
+		},
+		"WishListChange": {
+			Type:     "object",
+			Required: []string{"id", "kind", "element", "time"},
+			Properties: map[string]openAPISchema{
+				"id":      str,
+				"kind":    {Type: "string", Enum: []string{"added", "removed"}},
+				"element": schemaRef("WishListElement"),
+				"time":    dateTime,
+			},
+		},
+		"Webhook": {
+			Type:     "object",
+			Required: []string{"id", "url", "secret", "created_at"},
+			Properties: map[string]openAPISchema{
+				"id":         str,
+				"url":        url,
+				"secret":     {Type: "string", Description: "The key used to sign the payloads."},
+				"created_at": dateTime,
+			},
+		},
+		"WebhookDelivery": {
+			Type:     "object",
+			Required: []string{"id", "webhook_id", "url", "event", "attempt", "time"},
+			Properties: map[string]openAPISchema{
+				"id":          str,
+				"webhook_id":  str,
+				"url":         url,
+				"event":       str,
+				"attempt":     {Type: "integer"},
+				"status_code": {Type: "integer"},
+				"error":       str,
+				"time":        dateTime,
+			},
+		},
+		"Session": {
+			Type:     "object",
+			Required: []string{"user_id", "read_only"},
+			Properties: map[string]openAPISchema{
+				"session_id": {Type: "string", Description: "Not set for API tokens."},
+				"read_only":  {Type: "boolean"},
+				"user_id":    str,
+				"username":   str,
+				"user_email": str,
+				"expires_at": {
+					Type:        "string",
+					Format:      "date-time",
+					Description: "When the session ends at the latest. Not set for API tokens.",
+				},
+			},
+		},
+		"CalendarToken": {
+			Type:       "object",
+			Required:   []string{"token"},
+			Properties: map[string]openAPISchema{"token": str},
+		},
+		"APIToken": {
+			Type:     "object",
+			Required: []string{"id", "name", "read_only", "created_at"},
+			Properties: map[string]openAPISchema{
+				"id":         str,
+				"name":       str,
+				"read_only":  {Type: "boolean"},
+				"created_at": dateTime,
+			},
+		},
+		"CreatedAPIToken": {
+			Type:     "object",
+			Required: []string{"id", "name", "read_only", "created_at", "token"},
+			Properties: map[string]openAPISchema{
+				"id":         str,
+				"name":       str,
+				"read_only":  {Type: "boolean"},
+				"created_at": dateTime,
+				"token":      {Type: "string", Description: "The value of the token."},
+			},
+		},
+		"CreateAPITokenRequest": {
+			Type:     "object",
+			Required: []string{"name"},
+			Properties: map[string]openAPISchema{
+				"name":      name,
+				"read_only": {Type: "boolean"},
+			},
+		},
+		"CreateWishListRequest": {
+			Type:     "object",
+			Required: []string{"name", "username"},
+			Properties: map[string]openAPISchema{
+				"name":       name,
+				"username":   name,
+				"user_email": email,
+				"event_date": date,
+				"lang":       lang,
+				"webhook_urls": {
+					Type:        "array",
+					Description: "Webhooks to register on the list, receiving its creation.",
+					MaxItems:    10,
+					Items:       &url,
+				},
+			},
+		},
+		"CreatedWishList": {
+			Type:     "object",
+			Required: []string{"id", "admin_id"},
+			Properties: map[string]openAPISchema{
+				"id":       str,
+				"admin_id": str,
+			},
+		},
+		"UpdateListElementsRequest": {
+			Type:     "object",
+			Required: []string{"elements"},
+			Properties: map[string]openAPISchema{
+				"elements": {
+					Type:     "array",
+					MaxItems: 1000,
+					Items:    &openAPISchema{Ref: "#/components/schemas/WishListElement"},
+				},
+			},
+		},
+		"AddWebhookRequest": {
+			Type:       "object",
+			Required:   []string{"url"},
+			Properties: map[string]openAPISchema{"url": url},
+		},
+		"CreateGroupRequest": {
+			Type:     "object",
+			Required: []string{"name", "user_email"},
+			Properties: map[string]openAPISchema{
+				"name":       name,
+				"user_email": email,
+			},
+		},
+		"CreatedGroup": {
+			Type:       "object",
+			Required:   []string{"id"},
+			Properties: map[string]openAPISchema{"id": str},
+		},
+		"SendMagicLinkRequest": {
+			Type:       "object",
+			Required:   []string{"email"},
+			Properties: map[string]openAPISchema{"email": email, "lang": lang},
+		},
+		"CreateSessionRequest": {
+			Type:       "object",
+			Required:   []string{"magic_link_token"},
+			Properties: map[string]openAPISchema{"magic_link_token": str},
+		},
+	}
+}
+
+func pathParam(name string, description string) openAPIParameter {
+	return openAPIParameter{
+		Name:        name,
+		In:          "path",
+		Description: description,
+		Required:    true,
+		Schema:      openAPISchema{Type: "string"},
+	}
+}
+
+func schemaRef(name string) openAPISchema {
+	return openAPISchema{Ref: "#/components/schemas/" + name}
+}
+
+func arrayOf(name string) openAPISchema {
+	items := schemaRef(name)
+	return openAPISchema{Type: "array", Items: &items}
+}
+
+func jsonBody(schema openAPISchema) *openAPIRequestBody {
+	return &openAPIRequestBody{
+		Required: true,
+		Content:  map[string]openAPIMediaType{"application/json": {Schema: schema}},
+	}
+}
+
+func jsonResponse(description string, schema openAPISchema) openAPIResponse {
+	return openAPIResponse{
+		Description: description,
+		Content:     map[string]openAPIMediaType{"application/json": {Schema: schema}},
+	}
+}
+
+func bearerSecurity() []map[string][]string {
+	return []map[string][]string{{"session": {}}}
+}
+
+// responses returns the responses of an operation: the success response, and an
+// error response for each of the given error status codes.
+func responses(
+	successCode int,
+	success openAPIResponse,
+	errorCodes ...int,
+) map[string]openAPIResponse {
+	resp := map[string]openAPIResponse{
+		fmt.Sprint(successCode): success,
+	}
+
+	for _, code := range errorCodes {
+		resp[fmt.Sprint(code)] = jsonResponse(http.StatusText(code), schemaRef("Error"))
+	}
+
+	return resp
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestOpenAPIRoutes checks that the OpenAPI document describes exactly the routes of
+// the API, so it is updated each time a route is added or removed.
+func TestOpenAPIRoutes(t *testing.T) {
+	s := New(Config{BaseURL: "http://localhost:3000"})
+
+	routes := map[string]map[string]bool{}
+	err := chi.Walk(
+		s.router,
+		func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			path, found := strings.CutPrefix(route, apiPrefix)
+			if !found {
+				return nil
+			}
+
+			method = strings.ToLower(method)
+			if _, ok := s.openAPI.Paths[path][method]; !ok {
+				t.Errorf("route %s %s is missing from the OpenAPI document", method, route)
+			}
+
+			if routes[path] == nil {
+				routes[path] = map[string]bool{}
+			}
+			routes[path][method] = true
+
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, operations := range s.openAPI.Paths {
+		for method := range operations {
+			if !routes[path][method] {
+				t.Errorf("operation %s %s has no route", method, apiPrefix+path)
+			}
+		}
+	}
+}
@@ -3,35 +3,60 @@ package server
 import (
 	"io"
 	"net/http"
+
+	"github.com/go-chi/chi/v5"
 )
 
 func (s Server) setRoutes() {
 	s.router.Get("/", s.renderOKFunc(s.templates.RenderIndex, nil))
+	s.router.Post("/language", s.setLanguage)
 
-	s.router.Get("/login", s.renderOKFunc(s.templates.RenderLogin, nil))
+	s.router.Get("/login", s.renderOKFunc(s.templates.RenderLogin, ParamsLogin{}))
 	s.router.Post("/login", s.sendMagicLink)
-	s.router.Get("/login/magic/{token}", s.handleMagicLink)
+	s.router.Get("/login/magic/{token}", s.confirmMagicLink)
 	s.router.Get("/logout", s.logout)
 	s.router.Get("/lists", s.getUserWishLists)
+	s.router.Get("/lists/tokens", s.getAPITokens)
+	// The forms logging the user in or acting with their session must not be sent by
+	// another site.
+	s.router.Group(func(router chi.Router) {
+		router.Use(http.NewCrossOriginProtection().Handler)
+		router.Post("/login/magic/{token}", s.handleMagicLink)
+		router.Post("/lists/calendar", s.resetCalendar)
+		router.Post("/lists/tokens", s.createAPIToken)
+		router.Post("/lists/tokens/{tokenID}/delete", s.deleteAPIToken)
+	})
+	s.router.Get("/calendar/{token}.ics", s.getCalendar)
 
 	s.router.Get("/new", s.getNewWishList)
 	s.router.Post("/new", s.createNewWishList)
 
-	s.router.Get("/group/new", s.renderOKFunc(s.templates.RenderNewGroup, nil))
+	s.router.Get("/group/new", s.renderOKFunc(s.templates.RenderNewGroup, ParamsNewGroup{}))
 	s.router.Post("/group/new", s.createNewGroup)
 
 	s.router.Get("/l/{listID}", s.getWishList)
+	s.router.Get("/l/{listID}/print.pdf", s.printWishList)
+	s.router.Get("/l/{listID}/feed.atom", s.getWishListFeed)
 	s.router.Get("/l/{listID}/{adminID}", s.getWishList)
 	s.router.Get("/l/{listID}/{adminID}/edit", s.editList)
 	s.router.Post("/l/{listID}/{adminID}/edit", s.editList)
+	s.router.Get("/l/{listID}/{adminID}/webhooks", s.getWebhooks)
+	s.router.Post("/l/{listID}/{adminID}/webhooks", s.addWebhook)
+	s.router.Post("/l/{listID}/{adminID}/webhooks/{webhookID}/delete", s.deleteWebhook)
+
+	s.setAPIRoutes()
+
+	if s.devMode {
+		s.router.Get("/dev/emails", s.getDevEmails)
+	}
 
 	// 404 page
 	s.router.Get("/*", s.renderFunc(http.StatusNotFound, s.templates.RenderNotFoundError, nil))
 }
 
 func (s Server) renderOKFunc(templateFunc func(io.Writer, any) error, data any) http.HandlerFunc {
-	return func(w http.ResponseWriter, _ *http.Request) {
-		s.renderOK(w, templateFunc, data)
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.renderOK(w, r, templateFunc, data)
 	}
 }
 
@@ -40,24 +65,35 @@ func (s Server) renderFunc(
 	templateFunc func(io.Writer, any) error,
 	data any,
 ) http.HandlerFunc {
-	return func(w http.ResponseWriter, _ *http.Request) {
-		s.render(w, code, templateFunc, data)
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.render(w, r, code, templateFunc, data)
 	}
 }
 
-func (s Server) renderOK(w http.ResponseWriter, templateFunc func(io.Writer, any) error, data any) {
-	s.render(w, http.StatusOK, templateFunc, data)
+func (s Server) renderOK(
+	w http.ResponseWriter,
+	r *http.Request,
+	templateFunc func(io.Writer, any) error,
+	data any,
+) {
+	s.render(w, r, http.StatusOK, templateFunc, data)
 }
 
+// render renders a page in the language of the request.
 func (s Server) render(
 	w http.ResponseWriter,
+	r *http.Request,
 	code int,
 	templateFunc func(io.Writer, any) error,
 	data any,
 ) {
+	printer := s.printer(r)
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Language", printer.Lang())
+	w.Header().Set("Vary", "Accept-Language, Cookie")
 	w.WriteHeader(code)
-	err := templateFunc(w, data)
+	err := templateFunc(w, ParamsBase{Printer: printer, Branding: s.branding, Page: data})
 	if err != nil {
 		s.logger.Error("error while rendering template", "err", err)
 	}
@@ -11,6 +11,8 @@ import (
 type Templates interface {
 	RenderBase(wr io.Writer, data any) error
 	RenderBaseBytes(data any) ([]byte, error)
+	RenderDevEmails(wr io.Writer, data any) error
+	RenderDevEmailsBytes(data any) ([]byte, error)
 	RenderIndex(wr io.Writer, data any) error
 	RenderIndexBytes(data any) ([]byte, error)
 	RenderListAccessDenied(wr io.Writer, data any) error
@@ -21,8 +23,12 @@ type Templates interface {
 	RenderListNotFoundBytes(data any) ([]byte, error)
 	RenderListView(wr io.Writer, data any) error
 	RenderListViewBytes(data any) ([]byte, error)
+	RenderListWebhooks(wr io.Writer, data any) error
+	RenderListWebhooksBytes(data any) ([]byte, error)
 	RenderLogin(wr io.Writer, data any) error
 	RenderLoginBytes(data any) ([]byte, error)
+	RenderLoginMagic(wr io.Writer, data any) error
+	RenderLoginMagicBytes(data any) ([]byte, error)
 	RenderLogout(wr io.Writer, data any) error
 	RenderLogoutBytes(data any) ([]byte, error)
 	RenderNew(wr io.Writer, data any) error
@@ -33,48 +39,62 @@ type Templates interface {
 	RenderNotFoundErrorBytes(data any) ([]byte, error)
 	RenderUserListsView(wr io.Writer, data any) error
 	RenderUserListsViewBytes(data any) ([]byte, error)
+	RenderUserTokens(wr io.Writer, data any) error
+	RenderUserTokensBytes(data any) ([]byte, error)
 }
 type templates struct {
 	templateBase             *template.Template
+	templateDevEmails        *template.Template
 	templateIndex            *template.Template
 	templateListAccessDenied *template.Template
 	templateListEdit         *template.Template
 	templateListNotFound     *template.Template
 	templateListView         *template.Template
+	templateListWebhooks     *template.Template
 	templateLogin            *template.Template
+	templateLoginMagic       *template.Template
 	templateLogout           *template.Template
 	templateNew              *template.Template
 	templateNewGroup         *template.Template
 	templateNotFoundError    *template.Template
 	templateUserListsView    *template.Template
+	templateUserTokens       *template.Template
 }
 
 func NewTemplates() Templates {
-	baseTmpl := template.Must(template.New("base.html").Parse("{{ block \"base\" . }}\n<!doctype html>\n<html lang=\"en\">\n\n<head>\n    <meta charset=\"utf-8\">\n    <meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n    <title>Ma liste de vœux</title>\n    <link href=\"https://cdn.jsdelivr.net/npm/bootstrap@5.3.8/dist/css/bootstrap.min.css\" rel=\"stylesheet\"\n        integrity=\"sha384-sRIl4kxILFvY47J16cr9ZwB07vP4J8+LH7qKQnuqkuIAvNWLzeN8tE5YBujZqJLB\" crossorigin=\"anonymous\">\n    <script defer src=\"https://cdn.jsdelivr.net/npm/alpinejs@3.x.x/dist/cdn.min.js\"></script>\n    <script src=\"https://unpkg.com/htmx.org@2.0.4\"></script>\n</head>\n\n<body>\n    <div class=\"container\">\n        <nav class=\"navbar navbar-expand-lg navbar-light bg-light mb-4\">\n            <div class=\"container\">\n                <a class=\"navbar-brand\" href=\"/\">Ma liste de vœux</a>\n                <div class=\"d-flex\"><a class=\"btn btn-outline-primary\" href=\"/lists\">Mes listes de vœux</a></div>\n            </div>\n        </nav>\n        {{ block \"content\" . }} Nothing to see here. {{ end }}\n    </div>\n    <script src=\"https://cdn.jsdelivr.net/npm/bootstrap@5.3.8/dist/js/bootstrap.bundle.min.js\"\n        integrity=\"sha384-FKyoEForCGlyvwx9Hj09JcYn3nv7wiPVlz7YYwJrWVcXK/BmnVDxM+D2scQbITxI\"\n        crossorigin=\"anonymous\"></script>\n</body>\n\n</html>\n{{ end }}\n"))
-	userListsViewTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"mt-4\">\n    <div class=\"d-flex justify-content-between align-items-center mb-3\">\n        <h2 class=\"mb-0\">Mes listes de vœux</h2>\n            <div class=\"d-flex gap-2\">\n                <a href=\"/new\" class=\"btn btn-sm btn-primary\">Nouvelle liste</a>\n                <a href=\"/logout\" class=\"btn btn-sm btn-outline-secondary\">Se déconnecter</a>\n            </div>\n    </div>\n\n    {{ if not .Lists }}\n    <div class=\"alert alert-info\">Vous n'avez aucune liste pour le moment.</div>\n    {{ else }}\n    <div class=\"card shadow-sm\">\n        <div class=\"card-body p-0\">\n            <div class=\"table-responsive\">\n                <table class=\"table table-hover mb-0\">\n                    <thead class=\"table-light\">\n                        <tr>\n                            <th>Nom</th>\n                            <th class=\"text-end\">Actions</th>\n                        </tr>\n                    </thead>\n                    <tbody>\n                        {{ range .Lists }}\n                        <tr>\n                            <td class=\"align-middle position-relative\">{{ .Name }}\n                                <a href=\"/l/{{ .ID }}/{{ .AdminID }}\" class=\"stretched-link text-decoration-none\"\n                                    aria-label=\"Voir la liste\"></a>\n                            </td>\n                            <td class=\"text-end align-middle\">\n                                {{ if .AdminID }}\n                                <a href=\"/l/{{ .ID }}/{{ .AdminID }}/edit\"\n                                    class=\"btn btn-sm btn-outline-secondary\">Éditer</a>\n                                {{ end }}\n                            </td>\n                        </tr>\n                        {{ end }}\n                    </tbody>\n                </table>\n            </div>\n        </div>\n    </div>\n    {{ end }}\n</div>\n{{ end }}\n"))
-	notFoundErrorTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<p>Page inconnue</p>\n<p><a href=\"/\">Retourner à l'accueil</a></p>\n{{ end }}\n"))
-	newGroupTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div>\n    <h2>Créer un groupe</h2>\n    <form method=\"POST\" class=\"row g-3\">\n        <div class=\"col-12\">\n            <label for=\"name\" class=\"form-label\">Nom du groupe</label>\n            <input type=\"text\" class=\"form-control\" name=\"name\" id=\"name\" />\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"user\" class=\"form-label\">Votre nom d'utilisateur</label>\n            <input type=\"text\" class=\"form-control\" name=\"user\" id=\"user\" />\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"email\" class=\"form-label\">Votre adresse email</label>\n            <input type=\"email\" class=\"form-control\" name=\"email\" id=\"email\" />\n            <div class=\"form-text\">\n                Cela permet de recevoir le lien d'administration du groupe par email et de le\n                retrouver si vous l'avez perdue.\n            </div>\n        </div>\n\n        <div class=\"col-12\">\n            <button type=\"submit\" class=\"btn btn-primary\">Créer</button>\n        </div>\n    </form>\n</div>\n{{ end }}\n"))
-	newTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div>\n    <h2>Créer une liste de vœux</h2>\n    {{ if .Error }}\n    <div class=\"alert alert-danger\" role=\"alert\">\n        {{ .Error }}\n    </div>\n    {{ end }}\n    <form method=\"POST\" class=\"row g-3\">\n        <div class=\"col-12\">\n            <label for=\"name\" class=\"form-label\">Nom de la liste</label>\n            <input type=\"text\" class=\"form-control{{ if .NameError }} is-invalid{{ end }}\" name=\"name\" id=\"name\"\n                value=\"{{ .Name }}\" placeholder=\"Pour mon anniversaire\" />\n            {{ if .NameError }}<div class=\"invalid-feedback\">{{ .NameError }}</div>{{ end }}\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"user\" class=\"form-label\">Nom d'utilisateur</label>\n            <input type=\"text\" class=\"form-control{{ if .UserError }} is-invalid{{ end }}\" name=\"user\" id=\"user\"\n                value=\"{{ .User }}\" placeholder=\"George\" />\n            {{ if .UserError }}<div class=\"invalid-feedback\">{{ .UserError }}</div>{{ end }}\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"email\" class=\"form-label\">Adresse email (optionnel)</label>\n            <input type=\"email\" class=\"form-control{{ if .EmailError }} is-invalid{{ end }}\" name=\"email\" id=\"email\"\n                value=\"{{ .Email }}\" placeholder=\"george@example.org\" />\n            {{ if .EmailError }}<div class=\"invalid-feedback\">{{ .EmailError }}</div>{{ end }}\n            <div class=\"form-text\">\n                Cela permet de recevoir le lien de la liste de vœux par email et de la retrouver si vous l'avez perdue.\n            </div>\n        </div>\n\n        <div class=\"col-12\">\n            <button type=\"submit\" class=\"btn btn-primary\">Créer</button>\n        </div>\n    </form>\n</div>\n{{ end }}\n"))
-	logoutTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"row justify-content-center mt-4\">\n    <div class=\"col-md-6\">\n        <div class=\"card shadow-sm text-center\">\n            <div class=\"card-body\">\n                <h3 class=\"card-title\">Vous êtes déconnecté</h3>\n                <p class=\"text-muted\">Vous avez été déconnecté avec succès. À bientôt !</p>\n                <a href=\"/\" class=\"btn btn-primary mt-3\">Retour à l'accueil</a>\n            </div>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
-	loginTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"row justify-content-center\">\n    <div class=\"col-md-6\">\n        <div class=\"card shadow-sm mt-4\">\n            <div class=\"card-body\">\n                <h3 class=\"card-title\">Connexion par lien magique</h3>\n                <p class=\"text-muted\">\n                    Entrez votre adresse email. Vous recevrez un lien magique vous permettant de vous authentifier sans\n                    mot de passe.\n                </p>\n\n                {{ if .Error }}\n                <div class=\"alert alert-danger\" role=\"alert\">{{ .Error }}</div>\n                {{ end }}\n\n                {{ if .Sent}}\n                <div class=\"alert alert-success\" role=\"alert\">\n                    Un email contenant le lien de connexion a été envoyé à {{ .Email }}.\n                </div>\n                {{ else }}\n                <form method=\"POST\" class=\"row g-3\">\n                    <div class=\"col-12\">\n                        <label for=\"email\" class=\"form-label\">Adresse email</label>\n                        <input type=\"email\" name=\"email\" id=\"email\"\n                            class=\"form-control{{ if .EmailError }} is-invalid{{ end }}\" value=\"{{ .Email }}\" />\n                        {{ if .EmailError }}<div class=\"invalid-feedback\">{{ .EmailError }}</div>{{ end }}\n                        <div class=\"form-text\">Un lien de connexion sera envoyé à cette adresse.</div>\n                    </div>\n\n                    <div class=\"col-12 d-flex justify-content-end\">\n                        <button type=\"submit\" class=\"btn btn-primary\">Envoyer le lien magique</button>\n                    </div>\n                </form>\n                {{ end }}\n            </div>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
-	listViewTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"mt-3\">\n    <div class=\"d-flex justify-content-between align-items-center mb-3\">\n        <h2 class=\"mb-0\">{{ .Name }}<small class=\"text-muted fs-6 ms-2\">de {{ .Username }}</small></h2>\n        {{ if .AdminID }}\n        <a href=\"/l/{{ .ID }}/{{ .AdminID }}/edit\" class=\"btn btn-sm btn-secondary\">éditer</a>\n        {{ end }}\n    </div>\n\n    {{ if .GroupID }}\n    <p class=\"mb-3\">Cette liste fait partie d'un <a href=\"https://www.malistedevoeux.fr/g/{{ .GroupID }}\">groupe</a>.\n    </p>\n    {{ end }}\n\n    {{ if .AdminID }}\n    <div class=\"card mb-3\">\n        <div class=\"card-body\">\n            <p class=\"mb-1\"><strong>Lien à partager :</strong> <a\n                    href=\"/l/{{ .ID }}\">https://malistedevoeux.fr/l/{{ .ID }}</a></p>\n            <p class=\"mb-0\"><strong>Lien d'administration :</strong> <a\n                    href=\"/l/{{ .ID }}/{{ .AdminID }}\">https://malistedevoeux.fr/l/{{ .ID }}/{{\n                    .AdminID }}</a></p>\n        </div>\n    </div>\n    {{ end }}\n\n    <ul class=\"list-group\">\n        {{ range .Elements }}\n        <li class=\"list-group-item\">\n            <div class=\"d-flex w-100 justify-content-between\">\n                <h5 class=\"mb-1\">\n                    {{ .Name }}\n                    {{ if .URL }}\n                    <a href=\"{{ .URL }}\" target=\"_blank\" aria-label=\"ouvrir le lien\" class=\"text-decoration-none\">🔗</a>\n                    {{ end }}\n                </h5>\n            </div>\n            {{ if .Description }}<p class=\"mb-1 text-muted\">{{ .Description }}</p>{{ end }}\n        </li>\n        {{ end }}\n    </ul>\n</div>\n{{ end }}\n"))
-	listNotFoundTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<h2>Erreur</h2>\n\n<p>La liste n'a pas pu être trouvée.</p>\n\n<p><a href=\"/\">Retourner à l'accueil</a></p>\n{{ end }}\n"))
-	listEditTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<h2>Éditer la liste de vœux \"{{ .Name }}\"</h2>\n\n<form method=\"POST\" x-data='{ data: {{ .Data }} }' class=\"mt-3\">\n    <template x-for=\"(obj, index) in data\" :key=\"obj.id\">\n        <div class=\"card mb-3\">\n            <div class=\"card-body\">\n                <div class=\"row g-3\">\n                    <div class=\"col-md-6\">\n                        <label :for=\"`Elements-${index}-Name`\" class=\"form-label\">Nom</label>\n                        <input type=\"text\" :name=\"`Elements[${index}].Name`\" :id=\"`Elements-${index}-Name`\"\n                            x-model=\"data[index]['name']\" class=\"form-control\"\n                            x-bind:class=\"{ 'is-invalid': data[index]['name_error'] }\"\n                            x-bind:aria-describedby=\"data[index]['name_error'] ? `invalid-helper-${index}-name` : null\" />\n                        <div class=\"invalid-feedback\" :id=\"`invalid-helper-${index}-name`\"\n                            x-text=\"data[index]['name_error']\"></div>\n                    </div>\n\n                    <div class=\"col-md-6\">\n                        <label :for=\"`Elements-${index}-Description`\" class=\"form-label\">Description\n                            (optionnel)</label>\n                        <input type=\"text\" :name=\"`Elements[${index}].Description`\"\n                            :id=\"`Elements-${index}-Description`\" x-model=\"data[index]['description']\"\n                            class=\"form-control\" x-bind:class=\"{ 'is-invalid': data[index]['description_error'] }\"\n                            x-bind:aria-describedby=\"data[index]['description_error'] ? `invalid-helper-${index}-desc` : null\" />\n                        <div class=\"invalid-feedback\" :id=\"`invalid-helper-${index}-desc`\"\n                            x-text=\"data[index]['description_error']\"></div>\n                    </div>\n\n                    <div class=\"col-md-9\">\n                        <label :for=\"`Elements-${index}-URL`\" class=\"form-label\">Lien vers l'article (optionnel)</label>\n                        <input type=\"text\" :name=\"`Elements[${index}].URL`\" :id=\"`Elements-${index}-URL`\"\n                            x-model=\"data[index]['url']\" class=\"form-control\"\n                            x-bind:class=\"{ 'is-invalid': data[index]['url_error'] }\"\n                            x-bind:aria-describedby=\"data[index]['url_error'] ? `invalid-helper-${index}-url` : null\" />\n                        <div class=\"invalid-feedback\" :id=\"`invalid-helper-${index}-url`\"\n                            x-text=\"data[index]['url_error']\"></div>\n                    </div>\n\n                    <div class=\"col-12 col-md-3 d-flex align-items-end justify-content-end\">\n                        <button @click.prevent=\"data.splice(index, 1)\" type=\"button\"\n                            class=\"btn btn-sm btn-outline-danger p-2\" aria-label=\"Supprimer l'élément\">\n                            <svg xmlns=\"http://www.w3.org/2000/svg\" width=\"16\" height=\"16\" fill=\"currentColor\"\n                                class=\"bi bi-trash\" viewBox=\"0 0 16 16\">\n                                <path\n                                    d=\"M5.5 5.5A.5.5 0 0 1 6 6v6a.5.5 0 0 1-1 0V6a.5.5 0 0 1 .5-.5m2.5 0a.5.5 0 0 1 .5.5v6a.5.5 0 0 1-1 0V6a.5.5 0 0 1 .5-.5m3 .5a.5.5 0 0 0-1 0v6a.5.5 0 0 0 1 0z\" />\n                                <path\n                                    d=\"M14.5 3a1 1 0 0 1-1 1H13v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2V4h-.5a1 1 0 0 1-1-1V2a1 1 0 0 1 1-1H6a1 1 0 0 1 1-1h2a1 1 0 0 1 1 1h3.5a1 1 0 0 1 1 1zM4.118 4 4 4.059V13a1 1 0 0 0 1 1h6a1 1 0 0 0 1-1V4.059L11.882 4zM2.5 3h11V2h-11z\" />\n                            </svg>\n                            <span class=\"visually-hidden\">Supprimer</span>\n                        </button>\n                    </div>\n                </div>\n            </div>\n        </div>\n    </template>\n\n    <div class=\"mb-3\">\n        <button @click.prevent=\"data.push({ 'id': crypto.randomUUID(), 'name': '', 'description': '', 'url': ''})\"\n            type=\"button\" class=\"btn btn-secondary\">Ajouter un nouvel élément</button>\n    </div>\n\n    <div>\n        <button type=\"submit\" class=\"btn btn-primary\">Enregistrer</button>\n    </div>\n</form>\n{{ end }}\n"))
-	listAccessDeniedTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div>\n    L'URL est incorrect, vous ne pouvez pas éditer la liste de vœux {{ .Name }}.\n    Vous pouvez cependant <a href=\"/l/{{ .ID }}\">la consulter</a>.\n</div>\n{{ end }}\n"))
-	indexTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"row g-4 align-items-start\">\n    <div class=\"col-md-6\">\n        <div class=\"card h-100 shadow-sm\">\n            <div class=\"card-body d-flex flex-column\">\n                <h3 class=\"card-title\">Liste de vœux</h3>\n                <p class=\"card-text text-muted\">\n                    Vous pouvez créer une liste et y ajouter des éléments que vous souhaitez recevoir en cadeau.\n                </p>\n                <p class=\"card-text text-muted\">\n                    Cette liste pourra être consultée par toute personne disposant du lien.\n                </p>\n                <div class=\"mt-auto\">\n                    <a href=\"/new\" class=\"btn btn-primary\">Créer une nouvelle liste de vœux</a>\n                </div>\n            </div>\n        </div>\n    </div>\n\n    <div class=\"col-md-6\">\n        <div class=\"card h-100 shadow-sm\">\n            <div class=\"card-body d-flex flex-column\">\n                <h3 class=\"card-title\">Groupe (fonctionnalité à venir)</h3>\n                <p class=\"card-text text-muted\">\n                    Vous pouvez aussi créer un groupe.\n                </p>\n                <p class=\"card-text text-muted\">\n                    Chaque personne invitée dans le groupe via son adresse email pourra créer sa propre liste de vœux et voir celles des autres membres du groupe.\n                </p>\n                <div class=\"mt-auto\">\n                    <a href=\"/group/new\" class=\"btn btn-primary disabled\" aria-disabled=\"true\">Créer un groupe</a>\n                </div>\n            </div>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
+	baseTmpl := template.Must(template.New("base.html").Parse("{{ block \"base\" . }}\n<!doctype html>\n<html lang=\"{{ .Lang }}\">\n\n<head>\n    <meta charset=\"utf-8\">\n    <meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n    <title>{{ .Branding.Name }}</title>\n    <link href=\"https://cdn.jsdelivr.net/npm/bootstrap@5.3.8/dist/css/bootstrap.min.css\" rel=\"stylesheet\"\n        integrity=\"sha384-sRIl4kxILFvY47J16cr9ZwB07vP4J8+LH7qKQnuqkuIAvNWLzeN8tE5YBujZqJLB\" crossorigin=\"anonymous\">\n    <script defer src=\"https://cdn.jsdelivr.net/npm/alpinejs@3.x.x/dist/cdn.min.js\"></script>\n    <script src=\"https://unpkg.com/htmx.org@2.0.4\"></script>\n    {{ with .Branding.PrimaryColorRGB }}\n    <style>\n        :root {\n            --bs-primary: {{ $.Branding.PrimaryColor }};\n            --bs-primary-rgb: {{ . }};\n            --bs-link-color-rgb: {{ . }};\n            --bs-link-hover-color-rgb: {{ . }};\n        }\n\n        .btn-primary {\n            --bs-btn-bg: {{ $.Branding.PrimaryColor }};\n            --bs-btn-border-color: {{ $.Branding.PrimaryColor }};\n            --bs-btn-hover-bg: {{ $.Branding.PrimaryColor }};\n            --bs-btn-hover-border-color: {{ $.Branding.PrimaryColor }};\n            --bs-btn-active-bg: {{ $.Branding.PrimaryColor }};\n            --bs-btn-active-border-color: {{ $.Branding.PrimaryColor }};\n            --bs-btn-disabled-bg: {{ $.Branding.PrimaryColor }};\n            --bs-btn-disabled-border-color: {{ $.Branding.PrimaryColor }};\n        }\n\n        .btn-outline-primary {\n            --bs-btn-color: {{ $.Branding.PrimaryColor }};\n            --bs-btn-border-color: {{ $.Branding.PrimaryColor }};\n            --bs-btn-hover-bg: {{ $.Branding.PrimaryColor }};\n            --bs-btn-hover-border-color: {{ $.Branding.PrimaryColor }};\n            --bs-btn-active-bg: {{ $.Branding.PrimaryColor }};\n            --bs-btn-active-border-color: {{ $.Branding.PrimaryColor }};\n        }\n    </style>\n    {{ end }}\n</head>\n\n<body>\n    <div class=\"container\">\n        <nav class=\"navbar navbar-expand-lg navbar-light bg-light mb-4\">\n            <div class=\"container\">\n                <a class=\"navbar-brand\" href=\"/\">\n                    {{ with .Branding.LogoURL }}\n                    <img src=\"{{ . }}\" alt=\"\" height=\"30\" class=\"d-inline-block align-text-top me-1\">\n                    {{ end }}\n                    {{ .Branding.Name }}\n                </a>\n                <div class=\"d-flex gap-2\">\n                    <form method=\"POST\" action=\"/language\" class=\"btn-group\" role=\"group\"\n                        aria-label=\"{{ .T \"base.language\" }}\">\n                        <button type=\"submit\" name=\"lang\" value=\"fr\"\n                            class=\"btn btn-sm btn-outline-secondary{{ if eq .Lang \"fr\" }} active{{ end }}\">FR</button>\n                        <button type=\"submit\" name=\"lang\" value=\"en\"\n                            class=\"btn btn-sm btn-outline-secondary{{ if eq .Lang \"en\" }} active{{ end }}\">EN</button>\n                    </form>\n                    <a class=\"btn btn-outline-primary\" href=\"/lists\">{{ .T \"base.myLists\" }}</a>\n                </div>\n            </div>\n        </nav>\n        {{ block \"content\" . }} Nothing to see here. {{ end }}\n        {{ if or .Branding.FooterText .Branding.ContactEmail }}\n        <footer class=\"text-muted small text-center border-top mt-5 py-3\">\n            {{ with .Branding.FooterText }}<p class=\"mb-1\">{{ . }}</p>{{ end }}\n            {{ with .Branding.ContactEmail }}\n            <p class=\"mb-0\">{{ $.T \"base.contact\" }} <a href=\"mailto:{{ . }}\">{{ . }}</a></p>\n            {{ end }}\n        </footer>\n        {{ end }}\n    </div>\n    <script src=\"https://cdn.jsdelivr.net/npm/bootstrap@5.3.8/dist/js/bootstrap.bundle.min.js\"\n        integrity=\"sha384-FKyoEForCGlyvwx9Hj09JcYn3nv7wiPVlz7YYwJrWVcXK/BmnVDxM+D2scQbITxI\"\n        crossorigin=\"anonymous\"></script>\n</body>\n\n</html>\n{{ end }}\n"))
+	userTokensTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"mt-4\">\n    <div class=\"d-flex justify-content-between align-items-center mb-3\">\n        <h2 class=\"mb-0\">{{ .T \"userTokens.title\" }}</h2>\n        <a href=\"/lists\" class=\"btn btn-sm btn-outline-secondary\">{{ .T \"userTokens.back\" }}</a>\n    </div>\n\n    <p class=\"text-muted\">\n        {{ .T \"userTokens.help\" }}\n        <code>Authorization: Bearer &lt;{{ .T \"userTokens.help.token\" }}&gt;</code>. {{ .T \"userTokens.help.readOnly\" }}\n    </p>\n\n    {{ if .Page.Error }}\n    <div class=\"alert alert-danger\" role=\"alert\">{{ .Page.Error }}</div>\n    {{ end }}\n\n    {{ if .Page.NewToken }}\n    <div class=\"alert alert-success\" role=\"alert\">\n        <p>{{ .T \"userTokens.created\" }}</p>\n        <code>{{ .Page.NewToken }}</code>\n    </div>\n    {{ end }}\n\n    {{ if .Page.Tokens }}\n    <ul class=\"list-group mb-3\">\n        {{ range .Page.Tokens }}\n        <li class=\"list-group-item d-flex justify-content-between align-items-center\">\n            <div>\n                <div>{{ .Name }}{{ if .ReadOnly }} <span class=\"badge text-bg-secondary\">{{ $.T \"userTokens.readOnly.badge\" }}</span>{{ end }}</div>\n                <small class=\"text-muted\">{{ $.T \"userTokens.createdAt\" (.CreatedAt.Format ($.T \"common.dateLayout\")) }}</small>\n            </div>\n            <form method=\"POST\" action=\"/lists/tokens/{{ .ID }}/delete\">\n                <button type=\"submit\" class=\"btn btn-sm btn-outline-danger\">{{ $.T \"userTokens.revoke\" }}</button>\n            </form>\n        </li>\n        {{ end }}\n    </ul>\n    {{ else }}\n    <div class=\"alert alert-info\">{{ .T \"userTokens.empty\" }}</div>\n    {{ end }}\n\n    <form method=\"POST\" action=\"/lists/tokens\" class=\"row g-3 mb-4\">\n        <div class=\"col-md-6\">\n            <label for=\"name\" class=\"form-label\">{{ .T \"userTokens.name\" }}</label>\n            <input type=\"text\" class=\"form-control{{ if .Page.NameError }} is-invalid{{ end }}\" name=\"name\" id=\"name\"\n                value=\"{{ .Page.Name }}\" placeholder=\"{{ .T \"userTokens.name.placeholder\" }}\" />\n            {{ if .Page.NameError }}<div class=\"invalid-feedback\">{{ .Page.NameError }}</div>{{ end }}\n        </div>\n        <div class=\"col-md-3 d-flex align-items-end\">\n            <div class=\"form-check mb-2\">\n                <input class=\"form-check-input\" type=\"checkbox\" name=\"read_only\" id=\"read_only\" value=\"1\"\n                    {{ if .Page.ReadOnly }}checked{{ end }} />\n                <label class=\"form-check-label\" for=\"read_only\">{{ .T \"userTokens.readOnly\" }}</label>\n            </div>\n        </div>\n        <div class=\"col-md-3 d-flex align-items-end\">\n            <button type=\"submit\" class=\"btn btn-primary\">{{ .T \"common.create\" }}</button>\n        </div>\n    </form>\n</div>\n{{ end }}\n"))
+	userListsViewTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"mt-4\">\n    <div class=\"d-flex justify-content-between align-items-center mb-3\">\n        <h2 class=\"mb-0\">{{ .T \"userLists.title\" }}</h2>\n            <div class=\"d-flex gap-2\">\n                <a href=\"/new\" class=\"btn btn-sm btn-primary\">{{ .T \"userLists.new\" }}</a>\n                <a href=\"/logout\" class=\"btn btn-sm btn-outline-secondary\">{{ .T \"userLists.logout\" }}</a>\n            </div>\n    </div>\n\n    {{ if .Page.Error }}\n    <div class=\"alert alert-danger\" role=\"alert\">{{ .Page.Error }}</div>\n    {{ end }}\n\n    {{ if not .Page.Lists }}\n    <div class=\"alert alert-info\">{{ .T \"userLists.empty\" }}</div>\n    {{ else }}\n    <div class=\"card shadow-sm\">\n        <div class=\"card-body p-0\">\n            <div class=\"table-responsive\">\n                <table class=\"table table-hover mb-0\">\n                    <thead class=\"table-light\">\n                        <tr>\n                            <th>{{ .T \"userLists.name\" }}</th>\n                            <th>{{ .T \"userLists.date\" }}</th>\n                            <th class=\"text-end\">{{ .T \"userLists.actions\" }}</th>\n                        </tr>\n                    </thead>\n                    <tbody>\n                        {{ range .Page.Lists }}\n                        <tr>\n                            <td class=\"align-middle position-relative\">{{ .Name }}\n                                <a href=\"/l/{{ .ID }}/{{ .AdminID }}\" class=\"stretched-link text-decoration-none\"\n                                    aria-label=\"{{ $.T \"userLists.view\" }}\"></a>\n                            </td>\n                            <td class=\"align-middle\">\n                                {{ if not .EventDate.IsZero }}{{ .EventDate.Format ($.T \"common.dateLayout\") }}{{ end }}\n                            </td>\n                            <td class=\"text-end align-middle\">\n                                {{ if .AdminID }}\n                                <a href=\"/l/{{ .ID }}/{{ .AdminID }}/edit\"\n                                    class=\"btn btn-sm btn-outline-secondary\">{{ $.T \"userLists.edit\" }}</a>\n                                {{ end }}\n                            </td>\n                        </tr>\n                        {{ end }}\n                    </tbody>\n                </table>\n            </div>\n        </div>\n    </div>\n    {{ end }}\n\n    <div class=\"card shadow-sm mt-4\">\n        <div class=\"card-body\">\n            <h5 class=\"card-title\">{{ .T \"userLists.calendar\" }}</h5>\n            <p class=\"card-text text-muted\">\n                {{ .T \"userLists.calendar.help\" }}\n            </p>\n            {{ if .Page.CalendarURL }}\n            <p><code>{{ .Page.CalendarURL }}</code></p>\n            {{ end }}\n            <form method=\"POST\" action=\"/lists/calendar\">\n                <button type=\"submit\" class=\"btn btn-sm btn-outline-primary\">\n                    {{ if .Page.CalendarURL }}{{ .T \"userLists.calendar.reset\" }}{{ else }}{{ .T \"userLists.calendar.create\" }}{{ end }}\n                </button>\n            </form>\n        </div>\n    </div>\n\n    <div class=\"card shadow-sm mt-4\">\n        <div class=\"card-body\">\n            <h5 class=\"card-title\">{{ .T \"userLists.api\" }}</h5>\n            <p class=\"card-text text-muted\">\n                {{ .T \"userLists.api.help\" }}\n            </p>\n            <a href=\"/lists/tokens\" class=\"btn btn-sm btn-outline-primary\">{{ .T \"userLists.api.tokens\" }}</a>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
+	notFoundErrorTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<p>{{ .T \"notFound.message\" }}</p>\n<p><a href=\"/\">{{ .T \"common.backHome\" }}</a></p>\n{{ end }}\n"))
+	newGroupTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div>\n    <h2>{{ .T \"newGroup.title\" }}</h2>\n    {{ if .Page.Created }}\n    <div class=\"alert alert-success\" role=\"alert\">{{ .T \"newGroup.created\" .Page.Created }}</div>\n    {{ end }}\n    <form method=\"POST\" class=\"row g-3\">\n        <div class=\"col-12\">\n            <label for=\"name\" class=\"form-label\">{{ .T \"newGroup.name\" }}</label>\n            <input type=\"text\" class=\"form-control\" name=\"name\" id=\"name\" />\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"user\" class=\"form-label\">{{ .T \"newGroup.user\" }}</label>\n            <input type=\"text\" class=\"form-control\" name=\"user\" id=\"user\" />\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"email\" class=\"form-label\">{{ .T \"newGroup.email\" }}</label>\n            <input type=\"email\" class=\"form-control\" name=\"email\" id=\"email\" />\n            <div class=\"form-text\">\n                {{ .T \"newGroup.email.help\" }}\n            </div>\n        </div>\n\n        <div class=\"col-12\">\n            <button type=\"submit\" class=\"btn btn-primary\">{{ .T \"common.create\" }}</button>\n        </div>\n    </form>\n</div>\n{{ end }}\n"))
+	newTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div>\n    <h2>{{ .T \"new.title\" }}</h2>\n    {{ if .Page.Error }}\n    <div class=\"alert alert-danger\" role=\"alert\">\n        {{ .Page.Error }}\n    </div>\n    {{ end }}\n    <form method=\"POST\" class=\"row g-3\">\n        <div class=\"col-12\">\n            <label for=\"name\" class=\"form-label\">{{ .T \"new.name\" }}</label>\n            <input type=\"text\" class=\"form-control{{ if .Page.NameError }} is-invalid{{ end }}\" name=\"name\" id=\"name\"\n                value=\"{{ .Page.Name }}\" placeholder=\"{{ .T \"new.name.placeholder\" }}\" />\n            {{ if .Page.NameError }}<div class=\"invalid-feedback\">{{ .Page.NameError }}</div>{{ end }}\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"user\" class=\"form-label\">{{ .T \"new.user\" }}</label>\n            <input type=\"text\" class=\"form-control{{ if .Page.UserError }} is-invalid{{ end }}\" name=\"user\" id=\"user\"\n                value=\"{{ .Page.User }}\" placeholder=\"George\" />\n            {{ if .Page.UserError }}<div class=\"invalid-feedback\">{{ .Page.UserError }}</div>{{ end }}\n        </div>\n        <div class=\"col-md-6\">\n            <label for=\"email\" class=\"form-label\">{{ .T \"new.email\" }}</label>\n            <input type=\"email\" class=\"form-control{{ if .Page.EmailError }} is-invalid{{ end }}\" name=\"email\" id=\"email\"\n                value=\"{{ .Page.Email }}\" placeholder=\"george@example.org\" />\n            {{ if .Page.EmailError }}<div class=\"invalid-feedback\">{{ .Page.EmailError }}</div>{{ end }}\n            <div class=\"form-text\">\n                {{ .T \"new.email.help\" }}\n            </div>\n        </div>\n\n        <div class=\"col-md-6\">\n            <label for=\"event_date\" class=\"form-label\">{{ .T \"new.eventDate\" }}</label>\n            <input type=\"date\" class=\"form-control{{ if .Page.EventDateError }} is-invalid{{ end }}\" name=\"event_date\"\n                id=\"event_date\" value=\"{{ .Page.EventDate }}\" />\n            {{ if .Page.EventDateError }}<div class=\"invalid-feedback\">{{ .Page.EventDateError }}</div>{{ end }}\n            <div class=\"form-text\">\n                {{ .T \"new.eventDate.help\" }}\n            </div>\n        </div>\n\n        <div class=\"col-12\">\n            <button type=\"submit\" class=\"btn btn-primary\">{{ .T \"common.create\" }}</button>\n        </div>\n    </form>\n</div>\n{{ end }}\n"))
+	logoutTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"row justify-content-center mt-4\">\n    <div class=\"col-md-6\">\n        <div class=\"card shadow-sm text-center\">\n            <div class=\"card-body\">\n                <h3 class=\"card-title\">{{ .T \"logout.title\" }}</h3>\n                <p class=\"text-muted\">{{ .T \"logout.message\" }}</p>\n                <a href=\"/\" class=\"btn btn-primary mt-3\">{{ .T \"logout.backHome\" }}</a>\n            </div>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
+	loginMagicTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"row justify-content-center mt-4\">\n    <div class=\"col-md-6\">\n        <div class=\"card shadow-sm text-center\">\n            <div class=\"card-body\">\n                <h3 class=\"card-title\">{{ .T \"loginMagic.title\" }}</h3>\n                {{ if .Page.Confirm }}\n                <div class=\"alert alert-warning\" role=\"alert\">{{ .T \"loginMagic.otherBrowser\" }}</div>\n                {{ else }}\n                <p class=\"text-muted\">{{ .T \"loginMagic.description\" }}</p>\n                {{ end }}\n                <form method=\"POST\" action=\"/login/magic/{{ .Page.Token }}\">\n                    {{ if .Page.Confirm }}\n                    <input type=\"hidden\" name=\"confirm\" value=\"1\">\n                    <button type=\"submit\" class=\"btn btn-warning mt-3\">{{ .T \"loginMagic.confirm\" }}</button>\n                    {{ else }}\n                    <button type=\"submit\" class=\"btn btn-primary mt-3\">{{ .T \"loginMagic.submit\" }}</button>\n                    {{ end }}\n                </form>\n            </div>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
+	loginTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"row justify-content-center\">\n    <div class=\"col-md-6\">\n        <div class=\"card shadow-sm mt-4\">\n            <div class=\"card-body\">\n                <h3 class=\"card-title\">{{ .T \"login.title\" }}</h3>\n                <p class=\"text-muted\">\n                    {{ .T \"login.description\" }}\n                </p>\n\n                {{ if .Page.Error }}\n                <div class=\"alert alert-danger\" role=\"alert\">{{ .Page.Error }}</div>\n                {{ end }}\n\n                {{ if .Page.Sent}}\n                <div class=\"alert alert-success\" role=\"alert\">\n                    {{ .T \"login.sent\" .Page.Email }}\n                </div>\n                {{ else }}\n                <form method=\"POST\" action=\"/login\" class=\"row g-3\">\n                    <div class=\"col-12\">\n                        <label for=\"email\" class=\"form-label\">{{ .T \"login.email\" }}</label>\n                        <input type=\"email\" name=\"email\" id=\"email\"\n                            class=\"form-control{{ if .Page.EmailError }} is-invalid{{ end }}\" value=\"{{ .Page.Email }}\" />\n                        {{ if .Page.EmailError }}<div class=\"invalid-feedback\">{{ .Page.EmailError }}</div>{{ end }}\n                        <div class=\"form-text\">{{ .T \"login.email.help\" }}</div>\n                    </div>\n\n                    <div class=\"col-12 d-flex justify-content-end\">\n                        <button type=\"submit\" class=\"btn btn-primary\">{{ .T \"login.submit\" }}</button>\n                    </div>\n                </form>\n                {{ end }}\n            </div>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
+	listWebhooksTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"mt-3\">\n    <div class=\"d-flex justify-content-between align-items-center mb-3\">\n        <h2 class=\"mb-0\">{{ .T \"listWebhooks.title\" .Page.Name }}</h2>\n        <a href=\"/l/{{ .Page.ID }}/{{ .Page.AdminID }}\" class=\"btn btn-sm btn-outline-secondary\">{{ .T \"listWebhooks.back\" }}</a>\n    </div>\n\n    <p class=\"text-muted\">\n        {{ .T \"listWebhooks.help\" }} <code>X-Wishlister-Signature</code> {{ .T \"listWebhooks.help.contains\" }}\n        <code>sha256=</code> {{ .T \"listWebhooks.help.hmac\" }}\n    </p>\n\n    {{ if .Page.Webhooks }}\n    <ul class=\"list-group mb-3\">\n        {{ range .Page.Webhooks }}\n        <li class=\"list-group-item d-flex justify-content-between align-items-center\">\n            <div>\n                <div>{{ .URL }}</div>\n                <small class=\"text-muted\">{{ $.T \"listWebhooks.secret\" }} <code>{{ .Secret }}</code></small>\n            </div>\n            <form method=\"POST\" action=\"/l/{{ $.Page.ID }}/{{ $.Page.AdminID }}/webhooks/{{ .ID }}/delete\">\n                <button type=\"submit\" class=\"btn btn-sm btn-outline-danger\">{{ $.T \"common.delete\" }}</button>\n            </form>\n        </li>\n        {{ end }}\n    </ul>\n    {{ else }}\n    <div class=\"alert alert-info\">{{ .T \"listWebhooks.empty\" }}</div>\n    {{ end }}\n\n    <form method=\"POST\" class=\"row g-3 mb-4\">\n        <div class=\"col-md-9\">\n            <label for=\"url\" class=\"form-label\">{{ .T \"listWebhooks.url\" }}</label>\n            <input type=\"text\" class=\"form-control{{ if .Page.URLError }} is-invalid{{ end }}\" name=\"url\" id=\"url\"\n                value=\"{{ .Page.URL }}\" placeholder=\"https://example.org/webhook\" />\n            {{ if .Page.URLError }}<div class=\"invalid-feedback\">{{ .Page.URLError }}</div>{{ end }}\n        </div>\n        <div class=\"col-md-3 d-flex align-items-end\">\n            <button type=\"submit\" class=\"btn btn-primary\">{{ .T \"listWebhooks.add\" }}</button>\n        </div>\n    </form>\n\n    {{ if .Page.Deliveries }}\n    <h4>{{ .T \"listWebhooks.deliveries\" }}</h4>\n    <div class=\"table-responsive\">\n        <table class=\"table table-sm\">\n            <thead class=\"table-light\">\n                <tr>\n                    <th>{{ .T \"listWebhooks.deliveries.date\" }}</th>\n                    <th>{{ .T \"listWebhooks.deliveries.url\" }}</th>\n                    <th>{{ .T \"listWebhooks.deliveries.event\" }}</th>\n                    <th>{{ .T \"listWebhooks.deliveries.attempt\" }}</th>\n                    <th>{{ .T \"listWebhooks.deliveries.result\" }}</th>\n                </tr>\n            </thead>\n            <tbody>\n                {{ range .Page.Deliveries }}\n                <tr>\n                    <td>{{ .Time.Format ($.T \"common.dateTimeLayout\") }}</td>\n                    <td>{{ .URL }}</td>\n                    <td>{{ .Event }}</td>\n                    <td>{{ .Attempt }}</td>\n                    <td>\n                        {{ if .Error }}<span class=\"text-danger\">{{ .Error }}</span>\n                        {{ else }}<span class=\"text-success\">{{ .StatusCode }}</span>{{ end }}\n                    </td>\n                </tr>\n                {{ end }}\n            </tbody>\n        </table>\n    </div>\n    {{ end }}\n</div>\n{{ end }}\n"))
+	listViewTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"mt-3\">\n    <div class=\"d-flex justify-content-between align-items-center mb-3\">\n        <h2 class=\"mb-0\">{{ .Page.Name }}<small class=\"text-muted fs-6 ms-2\">{{ .T \"listView.by\" .Page.Username }}</small></h2>\n        <div class=\"d-flex gap-2\">\n            <a href=\"/l/{{ .Page.ID }}/feed.atom\" class=\"btn btn-sm btn-outline-secondary\"\n                title=\"{{ .T \"listView.follow.help\" }}\">{{ .T \"listView.follow\" }}</a>\n            <a href=\"/l/{{ .Page.ID }}/print.pdf\" class=\"btn btn-sm btn-outline-secondary\" target=\"_blank\">{{ .T \"listView.print\" }}</a>\n            {{ if .Page.AdminID }}\n            <a href=\"/l/{{ .Page.ID }}/{{ .Page.AdminID }}/webhooks\" class=\"btn btn-sm btn-outline-secondary\">{{ .T \"listView.webhooks\" }}</a>\n            <a href=\"/l/{{ .Page.ID }}/{{ .Page.AdminID }}/edit\" class=\"btn btn-sm btn-secondary\">{{ .T \"listView.edit\" }}</a>\n            {{ end }}\n        </div>\n    </div>\n\n    {{ if not .Page.EventDate.IsZero }}\n    <p class=\"mb-3 text-muted\">{{ .T \"listView.eventDate\" (.Page.EventDate.Format (.T \"common.dateLayout\")) }}</p>\n    {{ end }}\n\n    {{ if .Page.GroupID }}\n    <p class=\"mb-3\">{{ .T \"listView.group\" }}</p>\n    {{ end }}\n\n    {{ if .Page.AdminID }}\n    <div class=\"card mb-3\">\n        <div class=\"card-body\">\n            <p class=\"mb-1\"><strong>{{ .T \"listView.shareURL\" }}</strong> <a\n                    href=\"{{ .Page.ShareURL }}\">{{ .Page.ShareURL }}</a></p>\n            <p class=\"mb-0\"><strong>{{ .T \"listView.adminURL\" }}</strong> <a\n                    href=\"{{ .Page.AdminURL }}\">{{ .Page.AdminURL }}</a></p>\n        </div>\n    </div>\n    {{ end }}\n\n    <ul class=\"list-group\">\n        {{ range .Page.Elements }}\n        <li class=\"list-group-item\">\n            <div class=\"d-flex w-100 justify-content-between\">\n                <h5 class=\"mb-1\">\n                    {{ .Name }}\n                    {{ if .URL }}\n                    <a href=\"{{ .URL }}\" target=\"_blank\" aria-label=\"{{ $.T \"listView.openLink\" }}\" class=\"text-decoration-none\">🔗</a>\n                    {{ end }}\n                </h5>\n            </div>\n            {{ if .Description }}<p class=\"mb-1 text-muted\">{{ .Description }}</p>{{ end }}\n        </li>\n        {{ end }}\n    </ul>\n</div>\n{{ end }}\n"))
+	listNotFoundTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<h2>{{ .T \"common.error\" }}</h2>\n\n<p>{{ .T \"listNotFound.message\" }}</p>\n\n<p><a href=\"/\">{{ .T \"common.backHome\" }}</a></p>\n{{ end }}\n"))
+	listEditTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<h2>{{ .T \"listEdit.title\" .Page.Name }}</h2>\n\n<form method=\"POST\" x-data='{ data: {{ .Page.Data }} }' class=\"mt-3\">\n    <template x-for=\"(obj, index) in data\" :key=\"obj.id\">\n        <div class=\"card mb-3\">\n            <div class=\"card-body\">\n                <div class=\"row g-3\">\n                    <div class=\"col-md-6\">\n                        <label :for=\"`Elements-${index}-Name`\" class=\"form-label\">{{ .T \"listEdit.name\" }}</label>\n                        <input type=\"text\" :name=\"`Elements[${index}].Name`\" :id=\"`Elements-${index}-Name`\"\n                            x-model=\"data[index]['name']\" class=\"form-control\"\n                            x-bind:class=\"{ 'is-invalid': data[index]['name_error'] }\"\n                            x-bind:aria-describedby=\"data[index]['name_error'] ? `invalid-helper-${index}-name` : null\" />\n                        <div class=\"invalid-feedback\" :id=\"`invalid-helper-${index}-name`\"\n                            x-text=\"data[index]['name_error']\"></div>\n                    </div>\n\n                    <div class=\"col-md-6\">\n                        <label :for=\"`Elements-${index}-Description`\" class=\"form-label\">{{ .T \"listEdit.description\" }}</label>\n                        <input type=\"text\" :name=\"`Elements[${index}].Description`\"\n                            :id=\"`Elements-${index}-Description`\" x-model=\"data[index]['description']\"\n                            class=\"form-control\" x-bind:class=\"{ 'is-invalid': data[index]['description_error'] }\"\n                            x-bind:aria-describedby=\"data[index]['description_error'] ? `invalid-helper-${index}-desc` : null\" />\n                        <div class=\"invalid-feedback\" :id=\"`invalid-helper-${index}-desc`\"\n                            x-text=\"data[index]['description_error']\"></div>\n                    </div>\n\n                    <div class=\"col-md-9\">\n                        <label :for=\"`Elements-${index}-URL`\" class=\"form-label\">{{ .T \"listEdit.url\" }}</label>\n                        <input type=\"text\" :name=\"`Elements[${index}].URL`\" :id=\"`Elements-${index}-URL`\"\n                            x-model=\"data[index]['url']\" class=\"form-control\"\n                            x-bind:class=\"{ 'is-invalid': data[index]['url_error'] }\"\n                            x-bind:aria-describedby=\"data[index]['url_error'] ? `invalid-helper-${index}-url` : null\" />\n                        <div class=\"invalid-feedback\" :id=\"`invalid-helper-${index}-url`\"\n                            x-text=\"data[index]['url_error']\"></div>\n                    </div>\n\n                    <div class=\"col-12 col-md-3 d-flex align-items-end justify-content-end\">\n                        <button @click.prevent=\"data.splice(index, 1)\" type=\"button\"\n                            class=\"btn btn-sm btn-outline-danger p-2\" aria-label=\"{{ .T \"listEdit.deleteElement\" }}\">\n                            <svg xmlns=\"http://www.w3.org/2000/svg\" width=\"16\" height=\"16\" fill=\"currentColor\"\n                                class=\"bi bi-trash\" viewBox=\"0 0 16 16\">\n                                <path\n                                    d=\"M5.5 5.5A.5.5 0 0 1 6 6v6a.5.5 0 0 1-1 0V6a.5.5 0 0 1 .5-.5m2.5 0a.5.5 0 0 1 .5.5v6a.5.5 0 0 1-1 0V6a.5.5 0 0 1 .5-.5m3 .5a.5.5 0 0 0-1 0v6a.5.5 0 0 0 1 0z\" />\n                                <path\n                                    d=\"M14.5 3a1 1 0 0 1-1 1H13v9a2 2 0 0 1-2 2H5a2 2 0 0 1-2-2V4h-.5a1 1 0 0 1-1-1V2a1 1 0 0 1 1-1H6a1 1 0 0 1 1-1h2a1 1 0 0 1 1 1h3.5a1 1 0 0 1 1 1zM4.118 4 4 4.059V13a1 1 0 0 0 1 1h6a1 1 0 0 0 1-1V4.059L11.882 4zM2.5 3h11V2h-11z\" />\n                            </svg>\n                            <span class=\"visually-hidden\">{{ .T \"common.delete\" }}</span>\n                        </button>\n                    </div>\n                </div>\n            </div>\n        </div>\n    </template>\n\n    <div class=\"mb-3\">\n        <button @click.prevent=\"data.push({ 'id': crypto.randomUUID(), 'name': '', 'description': '', 'url': ''})\"\n            type=\"button\" class=\"btn btn-secondary\">{{ .T \"listEdit.addElement\" }}</button>\n    </div>\n\n    <div>\n        <button type=\"submit\" class=\"btn btn-primary\">{{ .T \"listEdit.save\" }}</button>\n    </div>\n</form>\n{{ end }}\n"))
+	listAccessDeniedTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div>\n    {{ .T \"listAccessDenied.message\" }}\n    <a href=\"/l/{{ .Page.ListID }}\">{{ .T \"listAccessDenied.view\" }}</a>.\n</div>\n{{ end }}\n"))
+	indexTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"row g-4 align-items-start\">\n    <div class=\"col-md-6\">\n        <div class=\"card h-100 shadow-sm\">\n            <div class=\"card-body d-flex flex-column\">\n                <h3 class=\"card-title\">{{ .T \"index.list.title\" }}</h3>\n                <p class=\"card-text text-muted\">\n                    {{ .T \"index.list.description\" }}\n                </p>\n                <p class=\"card-text text-muted\">\n                    {{ .T \"index.list.sharing\" }}\n                </p>\n                <div class=\"mt-auto\">\n                    <a href=\"/new\" class=\"btn btn-primary\">{{ .T \"index.list.create\" }}</a>\n                </div>\n            </div>\n        </div>\n    </div>\n\n    <div class=\"col-md-6\">\n        <div class=\"card h-100 shadow-sm\">\n            <div class=\"card-body d-flex flex-column\">\n                <h3 class=\"card-title\">{{ .T \"index.group.title\" }}</h3>\n                <p class=\"card-text text-muted\">\n                    {{ .T \"index.group.description\" }}\n                </p>\n                <p class=\"card-text text-muted\">\n                    {{ .T \"index.group.members\" }}\n                </p>\n                <div class=\"mt-auto\">\n                    <a href=\"/group/new\" class=\"btn btn-primary disabled\" aria-disabled=\"true\">{{ .T \"index.group.create\" }}</a>\n                </div>\n            </div>\n        </div>\n    </div>\n</div>\n{{ end }}\n"))
+	devEmailsTmpl := template.Must(template.Must(baseTmpl.Clone()).Parse("{{/* base: base.html */}}\n{{ define \"content\" }}\n<div class=\"mt-4\">\n    <h2>{{ .T \"devEmails.title\" }}</h2>\n    <p class=\"text-muted\">{{ .T \"devEmails.help\" }}</p>\n\n    {{ if .Page.Error }}\n    <div class=\"alert alert-danger\" role=\"alert\">{{ .Page.Error }}</div>\n    {{ end }}\n\n    {{ range .Page.Emails }}\n    <div class=\"card mb-4\">\n        <div class=\"card-header\">\n            <h5 class=\"mb-0\"><code>{{ .Name }}</code></h5>\n            <small class=\"text-muted\">{{ $.T \"devEmails.subject\" .Subject }}</small>\n        </div>\n        <div class=\"card-body\">\n            <ul class=\"nav nav-tabs mb-3\" role=\"tablist\">\n                <li class=\"nav-item\" role=\"presentation\">\n                    <button class=\"nav-link active\" id=\"{{ .Name }}-html-tab\" data-bs-toggle=\"tab\"\n                        data-bs-target=\"#{{ .Name }}-html\" type=\"button\" role=\"tab\"\n                        aria-controls=\"{{ .Name }}-html\" aria-selected=\"true\">{{ $.T \"devEmails.html\" }}</button>\n                </li>\n                <li class=\"nav-item\" role=\"presentation\">\n                    <button class=\"nav-link\" id=\"{{ .Name }}-text-tab\" data-bs-toggle=\"tab\"\n                        data-bs-target=\"#{{ .Name }}-text\" type=\"button\" role=\"tab\"\n                        aria-controls=\"{{ .Name }}-text\" aria-selected=\"false\">{{ $.T \"devEmails.text\" }}</button>\n                </li>\n            </ul>\n            <div class=\"tab-content\">\n                <div class=\"tab-pane fade show active\" id=\"{{ .Name }}-html\" role=\"tabpanel\"\n                    aria-labelledby=\"{{ .Name }}-html-tab\">\n                    <iframe srcdoc=\"{{ .HTML }}\" sandbox class=\"w-100 border\" style=\"height: 600px\"\n                        title=\"{{ .Name }}\"></iframe>\n                </div>\n                <div class=\"tab-pane fade\" id=\"{{ .Name }}-text\" role=\"tabpanel\"\n                    aria-labelledby=\"{{ .Name }}-text-tab\">\n                    <pre class=\"border p-3\">{{ .Text }}</pre>\n                </div>\n            </div>\n        </div>\n    </div>\n    {{ end }}\n</div>\n{{ end }}\n"))
 	return &templates{
 		templateBase:             baseTmpl,
+		templateDevEmails:        devEmailsTmpl,
 		templateIndex:            indexTmpl,
 		templateListAccessDenied: listAccessDeniedTmpl,
 		templateListEdit:         listEditTmpl,
 		templateListNotFound:     listNotFoundTmpl,
 		templateListView:         listViewTmpl,
+		templateListWebhooks:     listWebhooksTmpl,
 		templateLogin:            loginTmpl,
+		templateLoginMagic:       loginMagicTmpl,
 		templateLogout:           logoutTmpl,
 		templateNew:              newTmpl,
 		templateNewGroup:         newGroupTmpl,
 		templateNotFoundError:    notFoundErrorTmpl,
 		templateUserListsView:    userListsViewTmpl,
+		templateUserTokens:       userTokensTmpl,
 	}
 }
 func (t *templates) RenderBase(wr io.Writer, data any) error {
@@ -85,6 +105,14 @@ func (t *templates) RenderBaseBytes(data any) ([]byte, error) {
 	err := t.RenderBase(wr, data)
 	return wr.Bytes(), err
 }
+func (t *templates) RenderDevEmails(wr io.Writer, data any) error {
+	return t.templateDevEmails.Execute(wr, data)
+}
+func (t *templates) RenderDevEmailsBytes(data any) ([]byte, error) {
+	wr := &bytes.Buffer{}
+	err := t.RenderDevEmails(wr, data)
+	return wr.Bytes(), err
+}
 func (t *templates) RenderIndex(wr io.Writer, data any) error {
 	return t.templateIndex.Execute(wr, data)
 }
@@ -125,6 +153,14 @@ func (t *templates) RenderListViewBytes(data any) ([]byte, error) {
 	err := t.RenderListView(wr, data)
 	return wr.Bytes(), err
 }
+func (t *templates) RenderListWebhooks(wr io.Writer, data any) error {
+	return t.templateListWebhooks.Execute(wr, data)
+}
+func (t *templates) RenderListWebhooksBytes(data any) ([]byte, error) {
+	wr := &bytes.Buffer{}
+	err := t.RenderListWebhooks(wr, data)
+	return wr.Bytes(), err
+}
 func (t *templates) RenderLogin(wr io.Writer, data any) error {
 	return t.templateLogin.Execute(wr, data)
 }
@@ -133,6 +169,14 @@ func (t *templates) RenderLoginBytes(data any) ([]byte, error) {
 	err := t.RenderLogin(wr, data)
 	return wr.Bytes(), err
 }
+func (t *templates) RenderLoginMagic(wr io.Writer, data any) error {
+	return t.templateLoginMagic.Execute(wr, data)
+}
+func (t *templates) RenderLoginMagicBytes(data any) ([]byte, error) {
+	wr := &bytes.Buffer{}
+	err := t.RenderLoginMagic(wr, data)
+	return wr.Bytes(), err
+}
 func (t *templates) RenderLogout(wr io.Writer, data any) error {
 	return t.templateLogout.Execute(wr, data)
 }
@@ -173,3 +217,11 @@ func (t *templates) RenderUserListsViewBytes(data any) ([]byte, error) {
 	err := t.RenderUserListsView(wr, data)
 	return wr.Bytes(), err
 }
+func (t *templates) RenderUserTokens(wr io.Writer, data any) error {
+	return t.templateUserTokens.Execute(wr, data)
+}
+func (t *templates) RenderUserTokensBytes(data any) ([]byte, error) {
+	wr := &bytes.Buffer{}
+	err := t.RenderUserTokens(wr, data)
+	return wr.Bytes(), err
+}
@@ -1,15 +1,44 @@
 package server
 
+import (
+	"time"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/branding"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+	"github.com/erdnaxeli/wishlister/pkg/i18n"
+)
+
+// ParamsBase holds the parameters of the base template, common to all the pages.
+//
+// It is also given to the page template, which uses its T method to translate its
+// messages.
+type ParamsBase struct {
+	i18n.Printer
+
+	Branding branding.Branding
+	// Page holds the parameters of the page template.
+	Page any
+}
+
 // ParamsNew holds the parameters for the New template.
 type ParamsNew struct {
-	Name  string
-	User  string
-	Email string
+	Name      string
+	User      string
+	Email     string
+	EventDate string
 
-	Error      string
-	NameError  string
-	UserError  string
-	EmailError string
+	Error          string
+	NameError      string
+	UserError      string
+	EmailError     string
+	EventDateError string
+}
+
+// ParamsNewGroup holds the parameters for the NewGroup template.
+type ParamsNewGroup struct {
+	// Created is the name of the group just created, if any.
+	Created string
 }
 
 // ParamsLogin holds the parameters for the Login template.
@@ -22,16 +51,72 @@ type ParamsLogin struct {
 	Sent bool
 }
 
+// ParamsLoginMagic holds the parameters for the LoginMagic template.
+type ParamsLoginMagic struct {
+	Token string
+	// Confirm is true when the magic link was asked from another browser, and the user
+	// must confirm the login.
+	Confirm bool
+}
+
+// ParamsListView holds the parameters for the ListView template.
+type ParamsListView struct {
+	wishlister.WishList
+	// ShareURL is the public URL of the wishlist.
+	ShareURL string
+	// AdminURL is the URL of the administration page, or an empty string if the
+	// wishlist is not editable.
+	AdminURL string
+}
+
 // UserListsViewList represents a wishlist in the UserListsView template.
 type UserListsViewList struct {
-	AdminID string
-	ID      string
-	Name    string
+	AdminID   string
+	ID        string
+	Name      string
+	EventDate time.Time
 }
 
 // ParamsUserListsView holds the parameters for the UserListsView template.
 type ParamsUserListsView struct {
 	Lists []UserListsViewList
+	// CalendarURL is the URL of the user's calendar feed, or an empty string if the
+	// user has not generated one yet.
+	CalendarURL string
+
+	Error string
+}
+
+// ParamsUserTokens holds the parameters for the UserTokens template.
+type ParamsUserTokens struct {
+	Tokens []wishlister.APIToken
+	// NewToken is the value of the token just created, or an empty string.
+	NewToken string
+
+	Name     string
+	ReadOnly bool
+
+	NameError string
+	Error     string
+}
+
+// ParamsListWebhooks holds the parameters for the ListWebhooks template.
+type ParamsListWebhooks struct {
+	ID      string
+	AdminID string
+	Name    string
+
+	Webhooks   []wishlister.Webhook
+	Deliveries []wishlister.WebhookDelivery
+
+	URL string
+
+	URLError string
+}
+
+// ParamsDevEmails holds the parameters for the DevEmails template.
+type ParamsDevEmails struct {
+	Emails []email.Preview
 
 	Error string
 }
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestURLs checks that all the public URLs match a route, other than the not found
+// catch-all one, so a renamed route does not leave dead links in the emails and pages.
+func TestURLs(t *testing.T) {
+	// The app may be served under a path.
+	s := New(Config{BaseURL: "https://example.org/wishlister/"})
+
+	for _, publicURL := range []string{
+		s.urls.Home(),
+		s.urls.WishList("listID"),
+		s.urls.EditableWishList("listID", "adminID"),
+		s.urls.EditWishList("listID", "adminID"),
+		s.urls.WishListFeed("listID"),
+		s.urls.WishListPDF("listID"),
+		s.urls.UserWishLists(),
+		s.urls.MagicLink("token"),
+		s.urls.Calendar("token"),
+	} {
+		parsed, err := url.Parse(publicURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		path := strings.TrimPrefix(parsed.Path, "/wishlister")
+		pattern := s.router.Find(chi.NewRouteContext(), http.MethodGet, path)
+		if pattern == "" || pattern == "/*" {
+			t.Errorf("URL %s does not match any route", publicURL)
+		}
+	}
+}
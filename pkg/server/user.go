@@ -3,11 +3,19 @@ package server
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	nanoid "github.com/matoous/go-nanoid/v2"
+
+	"github.com/erdnaxeli/wishlister"
 )
 
+// loginBindingCookie is the name of the cookie binding the magic links to the browser
+// they were asked from.
+const loginBindingCookie = "login_binding"
+
 type sendMagicLinkForm struct {
 	Email string `form:"email" validate:"required,email,max=255"`
 }
@@ -19,22 +27,31 @@ func (s Server) sendMagicLink(w http.ResponseWriter, r *http.Request) {
 
 	err := s.validate.Struct(form)
 	if err != nil {
-		s.handleSendMagicLinkError(w, form, err)
+		s.handleSendMagicLinkError(w, r, form, err)
 		return
 	}
 
-	err = s.wishlister.SendMagicLink(r.Context(), form.Email)
+	ctx := wishlister.WithLoginBinding(r.Context(), s.setLoginBindingCookie(w, r))
+	err = s.wishlister.SendMagicLink(ctx, form.Email, s.language(r))
 	if err != nil {
+		if errors.Is(err, wishlister.ErrRateLimited) {
+			s.render(w, r, http.StatusTooManyRequests, s.templates.RenderLogin, ParamsLogin{
+				Error: s.printer(r).T("login.rateLimited"),
+				Email: form.Email,
+			})
+			return
+		}
+
 		s.logger.Error("failed to send magic link", "err", err)
 
-		s.renderOK(w, s.templates.RenderLogin, ParamsLogin{
-			Error: "Erreur lors de l'envoi du lien magique, veuillez réessayer.",
+		s.renderOK(w, r, s.templates.RenderLogin, ParamsLogin{
+			Error: s.printer(r).T("login.sendError"),
 			Email: form.Email,
 		})
 		return
 	}
 
-	s.renderOK(w, s.templates.RenderLogin, ParamsLogin{
+	s.renderOK(w, r, s.templates.RenderLogin, ParamsLogin{
 		Email: form.Email,
 		Sent:  true,
 	})
@@ -42,6 +59,7 @@ func (s Server) sendMagicLink(w http.ResponseWriter, r *http.Request) {
 
 func (s Server) handleSendMagicLinkError(
 	w http.ResponseWriter,
+	r *http.Request,
 	form sendMagicLinkForm,
 	validationErr error,
 ) {
@@ -53,8 +71,8 @@ func (s Server) handleSendMagicLinkError(
 	if errors.As(validationErr, &invalidErr) {
 		s.logger.Error("invalid validation error", "err", invalidErr)
 
-		params.Error = "Erreur lors de la soumission du formulaire, veuillez réessayer."
-		s.renderOK(w, s.templates.RenderLogin, params)
+		params.Error = s.printer(r).T("common.formError")
+		s.renderOK(w, r, s.templates.RenderLogin, params)
 	}
 
 	var validationErrors validator.ValidationErrors
@@ -62,8 +80,8 @@ func (s Server) handleSendMagicLinkError(
 		if len(validationErrors) == 0 {
 			// that should not happen
 			s.logger.Error("validation errors but length is 0")
-			params.Error = "Erreur lors de la soumission du formulaire, veuillez réessayer."
-			s.renderOK(w, s.templates.RenderLogin, params)
+			params.Error = s.printer(r).T("common.formError")
+			s.renderOK(w, r, s.templates.RenderLogin, params)
 		}
 
 		validationErr := validationErrors[0]
@@ -71,11 +89,11 @@ func (s Server) handleSendMagicLinkError(
 		case "Email":
 			switch validationErr.Tag() {
 			case "required":
-				params.EmailError = "L'adresse email est requise."
+				params.EmailError = s.printer(r).T("login.email.required")
 			case "email":
-				params.EmailError = "L'adresse email n'est pas valide."
+				params.EmailError = s.printer(r).T("login.email.invalid")
 			case "max":
-				params.EmailError = "L'adresse email est trop longue."
+				params.EmailError = s.printer(r).T("login.email.max")
 			default:
 				s.logger.Error("unknown email validation error tag", "tag", validationErr.Tag())
 			}
@@ -83,55 +101,128 @@ func (s Server) handleSendMagicLinkError(
 			s.logger.Error("unknown validation error field", "field", validationErr.Field())
 		}
 
-		s.renderOK(w, s.templates.RenderLogin, params)
+		s.renderOK(w, r, s.templates.RenderLogin, params)
 	}
 
 	s.logger.Error("unknown error during form validation", "err", validationErr)
-	params.Error = "Erreur lors de la soumission du formulaire, veuillez réessayer."
-	s.renderOK(w, s.templates.RenderLogin, params)
+	params.Error = s.printer(r).T("common.formError")
+	s.renderOK(w, r, s.templates.RenderLogin, params)
+}
+
+// setLoginBindingCookie returns the login binding of the browser, and saves it in a
+// cookie if it does not have one yet.
+//
+// The magic links asked by the browser can only be used with the same cookie.
+func (s Server) setLoginBindingCookie(w http.ResponseWriter, r *http.Request) string {
+	cookie, err := r.Cookie(loginBindingCookie)
+	if err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	binding, _ := nanoid.New()
+	http.SetCookie(w, &http.Cookie{
+		Name:     loginBindingCookie,
+		Value:    binding,
+		Path:     "/login",
+		MaxAge:   30 * 24 * 60 * 60,
+		Secure:   true,
+		HttpOnly: true,
+		// The cookie is not sent with a POST from another site, so a user cannot be
+		// logged in with a magic link of someone else.
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return binding
+}
+
+// confirmMagicLink asks the user to confirm the login, without using the magic link.
+//
+// The links of the emails are often opened by security scanners, which would use the
+// magic link before the user if it was used on GET.
+func (s Server) confirmMagicLink(w http.ResponseWriter, r *http.Request) {
+	s.renderOK(w, r, s.templates.RenderLoginMagic, ParamsLoginMagic{
+		Token: chi.URLParam(r, "token"),
+	})
 }
 
 func (s Server) handleMagicLink(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
-	session, err := s.wishlister.GetSessionByMagicLink(r.Context(), token)
+
+	var binding string
+	cookie, err := r.Cookie(loginBindingCookie)
+	if err == nil {
+		binding = cookie.Value
+	}
+
+	ctx := wishlister.WithLoginBinding(r.Context(), binding)
+	if r.PostFormValue("confirm") != "" {
+		ctx = wishlister.WithLoginConfirmed(ctx)
+	}
+
+	session, err := s.wishlister.GetSessionByMagicLink(ctx, token)
+	if errors.Is(err, wishlister.ErrLoginBindingMismatch) {
+		// The link was opened in another browser, or the cookie was deleted. The user is
+		// warned before being logged in, so they are not logged in to the account of
+		// someone else without noticing it.
+		s.renderOK(w, r, s.templates.RenderLoginMagic, ParamsLoginMagic{
+			Token:   token,
+			Confirm: true,
+		})
+		return
+	}
+
 	if err != nil {
 		s.logger.Error("failed to get session from magic link", "err", err)
-		s.renderOK(w, s.templates.RenderLogin, ParamsLogin{
-			Error: "Le lien magique est invalide ou a expiré. Veuillez réessayer.",
+		s.renderOK(w, r, s.templates.RenderLogin, ParamsLogin{
+			Error: s.printer(r).T("login.magicLinkInvalid"),
 		})
 		return
 	}
 
-	s.setUserSessionCookie(w, session.SessionID)
-	http.Redirect(w, r, "/lists", http.StatusFound)
+	s.setUserSessionCookie(w, session.SessionID, session.ExpiresAt)
+	http.Redirect(w, r, "/lists", http.StatusSeeOther)
 }
 
-func (s Server) setUserSessionCookie(w http.ResponseWriter, sessionID string, expire ...bool) {
+// setUserSessionCookie saves the session in a cookie expiring with it. A zero expiration
+// time deletes the cookie.
+func (s Server) setUserSessionCookie(w http.ResponseWriter, sessionID string, expires time.Time) {
 	cookie := &http.Cookie{
 		Name:     "session_id",
 		Value:    sessionID,
 		Path:     "/",
+		Expires:  expires,
 		Secure:   true,
 		HttpOnly: true,
+		// The cookie is not sent with the POST requests of other sites.
+		SameSite: http.SameSiteLaxMode,
 	}
 
-	if len(expire) > 0 && expire[0] {
+	if expires.IsZero() {
 		cookie.MaxAge = -1
 	}
 
 	http.SetCookie(w, cookie)
 }
 
-func (s Server) getUserWishLists(w http.ResponseWriter, r *http.Request) {
+// getSession returns the session of the current user.
+//
+// If the user is not logged in, or if the session is invalid, an error is returned.
+func (s Server) getSession(r *http.Request) (wishlister.Session, error) {
 	sessionIDCookie, err := r.Cookie("session_id")
 	if err != nil {
-		http.Redirect(w, r, "/login", http.StatusFound)
-		return
+		return wishlister.Session{}, err
 	}
 
-	session, err := s.wishlister.GetSession(r.Context(), sessionIDCookie.Value)
+	return s.wishlister.GetSession(r.Context(), sessionIDCookie.Value)
+}
+
+func (s Server) getUserWishLists(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getSession(r)
 	if err != nil {
-		s.logger.Error("failed to get session from cookie", "err", err)
+		if !errors.Is(err, http.ErrNoCookie) {
+			s.logger.Error("failed to get session from cookie", "err", err)
+		}
+
 		http.Redirect(w, r, "/login", http.StatusFound)
 		return
 	}
@@ -139,8 +230,8 @@ func (s Server) getUserWishLists(w http.ResponseWriter, r *http.Request) {
 	lists, err := s.wishlister.GetUserWishLists(r.Context(), session.UserID)
 	if err != nil {
 		s.logger.Error("failed to get user wish lists: ", "err", err)
-		s.renderOK(w, s.templates.RenderUserListsView, ParamsUserListsView{
-			Error: "Erreur lors de la récupération de vos listes de souhaits. Veuillez réessayer plus tard.",
+		s.renderOK(w, r, s.templates.RenderUserListsView, ParamsUserListsView{
+			Error: s.printer(r).T("userLists.error"),
 		})
 		return
 	}
@@ -148,13 +239,22 @@ func (s Server) getUserWishLists(w http.ResponseWriter, r *http.Request) {
 	params := ParamsUserListsView{}
 	for _, list := range lists {
 		params.Lists = append(params.Lists, UserListsViewList{
-			ID:      list.ID,
-			AdminID: list.AdminID,
-			Name:    list.Name,
+			ID:        list.ID,
+			AdminID:   list.AdminID,
+			Name:      list.Name,
+			EventDate: list.EventDate,
 		})
 	}
 
-	s.renderOK(w, s.templates.RenderUserListsView, params)
+	calendarToken, err := s.wishlister.GetUserCalendarToken(r.Context(), session.UserID)
+	if err != nil {
+		// The calendar is not critical, we still display the lists.
+		s.logger.Error("failed to get user calendar token", "err", err)
+	} else if calendarToken != "" {
+		params.CalendarURL = s.urls.Calendar(calendarToken)
+	}
+
+	s.renderOK(w, r, s.templates.RenderUserListsView, params)
 }
 
 func (s Server) logout(w http.ResponseWriter, r *http.Request) {
@@ -163,6 +263,6 @@ func (s Server) logout(w http.ResponseWriter, r *http.Request) {
 		s.wishlister.DeleteSession(r.Context(), sessionIDCookie.Value)
 	}
 
-	s.setUserSessionCookie(w, "", true)
-	s.renderOK(w, s.templates.RenderLogout, nil)
+	s.setUserSessionCookie(w, "", time.Time{})
+	s.renderOK(w, r, s.templates.RenderLogout, nil)
 }
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erdnaxeli/wishlister"
+)
+
+type createAPITokenForm struct {
+	Name     string `form:"name"      validate:"required,max=255"`
+	ReadOnly bool   `form:"read_only"`
+}
+
+func (s Server) getAPITokens(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getSession(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	s.renderAPITokens(w, r, session, ParamsUserTokens{})
+}
+
+func (s Server) createAPIToken(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getSession(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	form := createAPITokenForm{
+		Name:     r.PostFormValue("name"),
+		ReadOnly: r.PostFormValue("read_only") != "",
+	}
+
+	err = s.validate.Struct(form)
+	if err != nil {
+		s.renderAPITokens(w, r, session, ParamsUserTokens{
+			Name:      form.Name,
+			ReadOnly:  form.ReadOnly,
+			NameError: s.printer(r).T("userTokens.name.invalid"),
+		})
+		return
+	}
+
+	_, token, err := s.wishlister.CreateAPIToken(
+		r.Context(),
+		session.UserID,
+		form.Name,
+		form.ReadOnly,
+	)
+	if err != nil {
+		if errors.Is(err, wishlister.ErrAPITokenNameEmpty) {
+			s.renderAPITokens(w, r, session, ParamsUserTokens{
+				Name:      form.Name,
+				ReadOnly:  form.ReadOnly,
+				NameError: s.printer(r).T("userTokens.name.invalid"),
+			})
+			return
+		}
+
+		s.logger.Error("failed to create API token", "err", err)
+		s.renderAPITokens(w, r, session, ParamsUserTokens{
+			Name:     form.Name,
+			ReadOnly: form.ReadOnly,
+			Error:    s.printer(r).T("userTokens.createError"),
+		})
+		return
+	}
+
+	// The token is displayed only once, so we render the page instead of redirecting.
+	s.renderAPITokens(w, r, session, ParamsUserTokens{NewToken: token})
+}
+
+func (s Server) deleteAPIToken(w http.ResponseWriter, r *http.Request) {
+	session, err := s.getSession(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	err = s.wishlister.DeleteAPIToken(r.Context(), session.UserID, chi.URLParam(r, "tokenID"))
+	if err != nil && !errors.Is(err, wishlister.ErrAPITokenNotFound) {
+		s.logger.Error("failed to delete API token", "err", err)
+		s.renderAPITokens(w, r, session, ParamsUserTokens{
+			Error: s.printer(r).T("userTokens.deleteError"),
+		})
+		return
+	}
+
+	http.Redirect(w, r, "/lists/tokens", http.StatusSeeOther)
+}
+
+// renderAPITokens renders the API tokens page of a user.
+//
+// The given params are completed with the tokens of the user.
+func (s Server) renderAPITokens(
+	w http.ResponseWriter,
+	r *http.Request,
+	session wishlister.Session,
+	params ParamsUserTokens,
+) {
+	tokens, err := s.wishlister.GetAPITokens(r.Context(), session.UserID)
+	if err != nil {
+		s.logger.Error("failed to get API tokens", "err", err)
+		params.Error = s.printer(r).T("userTokens.listError")
+	}
+
+	params.Tokens = tokens
+	s.renderOK(w, r, s.templates.RenderUserTokens, params)
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAPITokensCrossOrigin checks that the forms managing the API tokens cannot be sent
+// by another site.
+func TestAPITokensCrossOrigin(t *testing.T) {
+	s := New(Config{BaseURL: "https://example.org"})
+
+	for _, path := range []string{"/lists/tokens", "/lists/tokens/tokenID/delete"} {
+		for _, test := range []struct {
+			fetchSite string
+			expected  int
+		}{
+			{fetchSite: "cross-site", expected: http.StatusForbidden},
+			{fetchSite: "same-site", expected: http.StatusForbidden},
+			// Without a session, the user is sent to the login page.
+			{fetchSite: "same-origin", expected: http.StatusFound},
+		} {
+			r := httptest.NewRequest(http.MethodPost, path, strings.NewReader("name=test"))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("Sec-Fetch-Site", test.fetchSite)
+			w := httptest.NewRecorder()
+
+			s.ServeHTTP(w, r)
+
+			if w.Code != test.expected {
+				t.Errorf(
+					"%s from %s: got %d, expected %d",
+					path,
+					test.fetchSite,
+					w.Code,
+					test.expected,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package urls builds the public URLs of the app.
+//
+// All the absolute links given to the users, in the web pages, the feeds or the
+// emails, are built with it, so they all use the configured base URL.
+package urls
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Builder builds the public URLs from a base URL.
+type Builder struct {
+	baseURL string
+}
+
+// New returns a new Builder for the given base URL, like "https://example.org".
+func New(baseURL string) Builder {
+	return Builder{baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Home returns the URL of the home page.
+func (b Builder) Home() string {
+	return b.baseURL + "/"
+}
+
+// WishList returns the URL to share a wishlist.
+func (b Builder) WishList(listID string) string {
+	return b.url("l", listID)
+}
+
+// EditableWishList returns the URL of the administration page of a wishlist.
+func (b Builder) EditableWishList(listID string, adminID string) string {
+	return b.url("l", listID, adminID)
+}
+
+// EditWishList returns the URL of the edition form of a wishlist.
+func (b Builder) EditWishList(listID string, adminID string) string {
+	return b.url("l", listID, adminID, "edit")
+}
+
+// WishListFeed returns the URL of the Atom feed of a wishlist.
+func (b Builder) WishListFeed(listID string) string {
+	return b.url("l", listID, "feed.atom")
+}
+
+// WishListPDF returns the URL of the printable version of a wishlist.
+func (b Builder) WishListPDF(listID string) string {
+	return b.url("l", listID, "print.pdf")
+}
+
+// UserWishLists returns the URL of the page listing the wishlists of the logged in
+// user.
+func (b Builder) UserWishLists() string {
+	return b.url("lists")
+}
+
+// MagicLink returns the URL to log in with a magic link token.
+func (b Builder) MagicLink(token string) string {
+	return b.url("login", "magic", token)
+}
+
+// Calendar returns the URL of the iCalendar feed of a calendar token.
+func (b Builder) Calendar(token string) string {
+	return b.url("calendar", token+".ics")
+}
+
+// ParseWishList returns the IDs of the wishlist of a link given by WishList,
+// EditableWishList or EditWishList, whatever its base URL.
+//
+// The admin ID is empty for a link given by WishList. The other links, like the one of
+// the feed, are rejected.
+func ParseWishList(link string) (listID string, adminID string, ok bool) {
+	parsedURL, err := url.Parse(link)
+	if err != nil || parsedURL.Host == "" {
+		return "", "", false
+	}
+
+	// The app may be served under a path, so the "l" segment is looked for in the last
+	// segments, as the links of a list have at most 3 segments after it.
+	base := parsedURL.Scheme + "://" + parsedURL.Host
+	path := strings.TrimSuffix(parsedURL.EscapedPath(), "/")
+	link = base + path
+	segments := strings.Split(path, "/")
+	for i := max(len(segments)-4, 0); i < len(segments)-1; i++ {
+		if segments[i] != "l" {
+			continue
+		}
+
+		b := New(base + strings.Join(segments[:i], "/"))
+		listID, err = url.PathUnescape(segments[i+1])
+		if err != nil {
+			return "", "", false
+		}
+
+		switch link {
+		case b.WishList(listID):
+			return listID, "", true
+		case b.WishListFeed(listID), b.WishListPDF(listID):
+			return "", "", false
+		}
+
+		if len(segments) < i+3 {
+			return "", "", false
+		}
+
+		adminID, err = url.PathUnescape(segments[i+2])
+		if err != nil {
+			return "", "", false
+		}
+
+		switch link {
+		case b.EditableWishList(listID, adminID), b.EditWishList(listID, adminID):
+			return listID, adminID, true
+		}
+
+		return "", "", false
+	}
+
+	return "", "", false
+}
+
+// url joins the given segments to the base URL, escaping each of them.
+func (b Builder) url(segments ...string) string {
+	var builder strings.Builder
+	builder.WriteString(b.baseURL)
+	for _, segment := range segments {
+		builder.WriteString("/")
+		builder.WriteString(url.PathEscape(segment))
+	}
+
+	return builder.String()
+}
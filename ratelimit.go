@@ -0,0 +1,115 @@
+package wishlister
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+// clientIPKey is the context key of the IP address of the client.
+type clientIPKey struct{}
+
+// WithClientIP returns a context holding the IP address of the client doing the
+// request.
+//
+// It is used to rate limit the operations sending emails. Without it, only the
+// recipients are rate limited.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// clientIP returns the IP address of the client, or an empty string if it is unknown.
+func clientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// rateLimit is the maximum number of hits allowed for a key during a window.
+type rateLimit struct {
+	key   string
+	limit int64
+}
+
+// checkEmailRateLimits returns ErrRateLimited if too many emails were sent to the
+// given recipient or asked by the client during the current window. Else it counts
+// the email to send.
+//
+// The counters are saved in the database, so they are kept across restarts and shared
+// between the instances.
+func (a *app) checkEmailRateLimits(ctx context.Context, recipient string) error {
+	windowStart := time.Now().Truncate(a.rateLimitWindow).Unix()
+
+	// The counters of the previous windows are useless.
+	err := a.queries.DeleteExpiredRateLimits(ctx, windowStart)
+	if err != nil {
+		return err
+	}
+
+	limits := []rateLimit{
+		{key: "recipient:" + strings.ToLower(recipient), limit: a.recipientEmailLimit},
+	}
+	if ip := clientIP(ctx); ip != "" {
+		limits = append(limits, rateLimit{
+			key:   "ip:" + ipRateLimitKey(ip),
+			limit: a.clientEmailLimit,
+		})
+	}
+
+	// All the limits are checked before counting the email, so a refused email does
+	// not use the quota of the other counter. Concurrent requests may both pass the
+	// check, letting at worst a few more emails through.
+	for _, limit := range limits {
+		hits, err := a.queries.GetRateLimitHits(ctx, repository.GetRateLimitHitsParams{
+			Key:         limit.key,
+			WindowStart: windowStart,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			hits = 0
+		}
+
+		if hits >= limit.limit {
+			return ErrRateLimited
+		}
+	}
+
+	for _, limit := range limits {
+		_, err := a.queries.HitRateLimit(ctx, repository.HitRateLimitParams{
+			Key:         limit.key,
+			WindowStart: windowStart,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ipRateLimitKey returns the key used to rate limit an IP address.
+//
+// An IPv6 client usually owns a whole /64 network, so it is limited as a whole.
+func ipRateLimitKey(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+
+	addr = addr.Unmap()
+	if addr.Is6() {
+		prefix, err := addr.Prefix(64)
+		if err == nil {
+			return prefix.String()
+		}
+	}
+
+	return addr.String()
+}
@@ -0,0 +1,66 @@
+package wishlister_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+	"golang.org/x/text/language"
+)
+
+func TestEmailRateLimits(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		app, err := wishlister.NewWithConfig(wishlister.Config{
+			DB:          db,
+			Engine:      engine,
+			EmailSender: email.NoMailer{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sendMagicLink := func(ip string, recipient string) error {
+			ctx := wishlister.WithClientIP(context.Background(), ip)
+			return app.SendMagicLink(ctx, recipient, language.English)
+		}
+
+		// 5 emails per recipient.
+		for i := range 5 {
+			err = sendMagicLink("192.0.2.1", "alice@example.com")
+			if err != nil {
+				t.Fatalf("email %d: %s", i, err)
+			}
+		}
+
+		err = sendMagicLink("192.0.2.1", "alice@example.com")
+		if !errors.Is(err, wishlister.ErrRateLimited) {
+			t.Fatalf("got %v, expected ErrRateLimited", err)
+		}
+
+		// 20 emails per client, the refused one is not counted.
+		for i := range 15 {
+			err = sendMagicLink("192.0.2.1", fmt.Sprintf("user%d@example.com", i))
+			if err != nil {
+				t.Fatalf("email %d: %s", i, err)
+			}
+		}
+
+		err = sendMagicLink("192.0.2.1", "bob@example.com")
+		if !errors.Is(err, wishlister.ErrRateLimited) {
+			t.Fatalf("got %v, expected ErrRateLimited", err)
+		}
+
+		// The email refused to the client is not counted for the recipient.
+		for i := range 5 {
+			err = sendMagicLink("192.0.2.2", "bob@example.com")
+			if err != nil {
+				t.Fatalf("email %d: %s", i, err)
+			}
+		}
+	})
+}
@@ -5,44 +5,115 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"time"
 
 	nanoid "github.com/matoous/go-nanoid/v2"
+	"golang.org/x/text/language"
 
 	"github.com/erdnaxeli/wishlister/pkg/repository"
 )
 
+// sessionTouchInterval is the interval at which the last use of a session is saved.
+const sessionTouchInterval = time.Minute
+
+// loginBindingKey is the context key of the login binding of the client.
+type loginBindingKey struct{}
+
+// WithLoginBinding returns a context holding a secret identifying the browser doing the
+// request, like a random value saved in a cookie.
+//
+// A magic link asked with a login binding can only be used with the same binding, so
+// it cannot be used from another browser without a confirmation (see
+// WithLoginConfirmed). Without it, the magic link can only be used without a binding
+// too, like from the API.
+func WithLoginBinding(ctx context.Context, binding string) context.Context {
+	return context.WithValue(ctx, loginBindingKey{}, binding)
+}
+
+// loginBinding returns the login binding of the client, or an empty string if there
+// is none.
+func loginBinding(ctx context.Context) string {
+	binding, _ := ctx.Value(loginBindingKey{}).(string)
+	return binding
+}
+
+// loginConfirmedKey is the context key telling the user confirmed the login.
+type loginConfirmedKey struct{}
+
+// WithLoginConfirmed returns a context telling the user explicitly confirmed the login,
+// after being warned that the magic link was asked from another client.
+//
+// A magic link can then be used whatever its login binding.
+func WithLoginConfirmed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loginConfirmedKey{}, true)
+}
+
+// loginConfirmed returns whether the user confirmed the login.
+func loginConfirmed(ctx context.Context) bool {
+	confirmed, _ := ctx.Value(loginConfirmedKey{}).(bool)
+	return confirmed
+}
+
 // GetOrCreateUser retrieves an existing user by email or creates a new one.
 //
-// It returns the user ID.
+// The given language is saved as the language of the user, unless it is undefined.
+// It returns the user ID and their language.
 func (a *app) GetOrCreateUser(
 	ctx context.Context,
 	username string,
 	email string,
-) (string, error) {
+	lang language.Tag,
+) (string, language.Tag, error) {
 	userID, _ := nanoid.New()
 
+	var langTag string
+	if lang != language.Und {
+		langTag = lang.String()
+	}
+
 	user, err := a.queries.GetOrCreateUser(ctx, repository.GetOrCreateUserParams{
 		ID:    userID,
 		Name:  username,
 		Email: email,
+		Lang:  langTag,
 	})
 	if err != nil {
-		return "", err
+		return "", language.Und, err
 	}
 
-	return user.ID, nil
+	// An unknown language is undefined.
+	return user.ID, language.Make(user.Lang), nil
 }
 
 func (a *app) createUserSession(
 	ctx context.Context,
 	userID string,
 ) (Session, error) {
+	now := time.Now()
+
+	// The expired sessions are cleaned up here, as a new session is a good time to do
+	// it and is not frequent.
+	_, err := a.queries.DeleteExpiredUserSessions(
+		ctx,
+		repository.DeleteExpiredUserSessionsParams{
+			LoggedInBefore:         now.Add(-a.sessionLifetime).Unix(),
+			LastUsedBefore:         now.Add(-a.sessionIdleTimeout).Unix(),
+			MagicLinkCreatedBefore: now.Add(-a.magicLinkTTL).Unix(),
+		},
+	)
+	if err != nil {
+		return Session{}, err
+	}
+
 	sessionID, _ := nanoid.New()
 	magicLinkToken, _ := nanoid.New()
-	err := a.queries.CreateUserSession(ctx, repository.CreateUserSessionParams{
-		ID:             sessionID,
-		UserID:         userID,
-		MagicLinkToken: NewNullString(magicLinkToken),
+	err = a.queries.CreateUserSession(ctx, repository.CreateUserSessionParams{
+		ID:               sessionID,
+		UserID:           userID,
+		MagicLinkToken:   NewNullString(magicLinkToken),
+		MagicLinkBinding: loginBinding(ctx),
+		CreatedAt:        now.Unix(),
+		LastUsedAt:       now.Unix(),
 	})
 	if err != nil {
 		return Session{}, err
@@ -55,8 +126,13 @@ func (a *app) createUserSession(
 	}, nil
 }
 
-func (a *app) SendMagicLink(ctx context.Context, email string) error {
-	userID, err := a.GetOrCreateUser(ctx, "", email)
+func (a *app) SendMagicLink(ctx context.Context, email string, lang language.Tag) error {
+	err := a.checkEmailRateLimits(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	userID, lang, err := a.GetOrCreateUser(ctx, "", email, lang)
 	if err != nil {
 		return err
 	}
@@ -66,7 +142,7 @@ func (a *app) SendMagicLink(ctx context.Context, email string) error {
 		return err
 	}
 
-	return a.emailSender.SendMagicLink(ctx, email, session.MagicLinkToken)
+	return a.emailSender.SendMagicLink(ctx, email, lang, session.MagicLinkToken)
 }
 
 func (a *app) GetSession(ctx context.Context, sessionID string) (Session, error) {
@@ -79,20 +155,71 @@ func (a *app) GetSession(ctx context.Context, sessionID string) (Session, error)
 		return Session{}, err
 	}
 
+	now := time.Now()
+	expiresAt := a.sessionExpiresAt(session.LoggedInAt)
+	lastUsedAt := time.Unix(session.LastUsedAt, 0)
+	if now.After(expiresAt) || now.After(lastUsedAt.Add(a.sessionIdleTimeout)) {
+		a.DeleteSession(ctx, sessionID)
+		return Session{}, ErrSessionNotFound
+	}
+
+	// The last use is only saved from time to time, to not write on each request.
+	if now.Sub(lastUsedAt) > sessionTouchInterval {
+		err = a.queries.TouchUserSession(ctx, repository.TouchUserSessionParams{
+			LastUsedAt: now.Unix(),
+			ID:         sessionID,
+		})
+		if err != nil {
+			return Session{}, err
+		}
+	}
+
 	return Session{
 		UserID:    session.UserID,
 		Username:  session.Username,
 		UserEmail: session.UserEmail,
 		SessionID: session.ID,
+		ExpiresAt: expiresAt,
 	}, nil
 }
 
 // GetSessionByMagicLink returns the session associated with the given magic link token.
 //
-// If the session is not found, an error ErrSessionNotFound is returned.
+// If the session is not found, or if the magic link has expired, an error
+// ErrSessionNotFound is returned. If the login binding is not the one the link was
+// asked with, and the login was not confirmed, an error ErrLoginBindingMismatch is
+// returned and the magic link can still be used.
 // Once used, the magic link token is invalidated and cannot be used again.
 func (a *app) GetSessionByMagicLink(ctx context.Context, token string) (Session, error) {
-	session, err := a.queries.GetUserSessionByMagicLink(ctx, NewNullString(token))
+	now := time.Now()
+	createdAfter := now.Add(-a.magicLinkTTL).Unix()
+	binding, err := a.queries.GetMagicLinkBinding(ctx, repository.GetMagicLinkBindingParams{
+		MagicLinkToken: NewNullString(token),
+		CreatedAfter:   createdAfter,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Session{}, ErrSessionNotFound
+		}
+
+		return Session{}, err
+	}
+
+	if binding != loginBinding(ctx) && !loginConfirmed(ctx) {
+		return Session{}, ErrLoginBindingMismatch
+	}
+
+	// The binding is checked again, so the link is used only once even if it is used by
+	// concurrent requests.
+	session, err := a.queries.GetUserSessionByMagicLink(
+		ctx,
+		repository.GetUserSessionByMagicLinkParams{
+			LoggedInAt:       now.Unix(),
+			MagicLinkToken:   NewNullString(token),
+			MagicLinkBinding: binding,
+			CreatedAfter:     createdAfter,
+		},
+	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return Session{}, ErrSessionNotFound
@@ -104,9 +231,16 @@ func (a *app) GetSessionByMagicLink(ctx context.Context, token string) (Session,
 	return Session{
 		UserID:    session.UserID,
 		SessionID: session.ID,
+		ExpiresAt: a.sessionExpiresAt(session.LoggedInAt),
 	}, nil
 }
 
+// sessionExpiresAt returns the end of the lifetime of a session whose magic link was
+// used at the given Unix time.
+func (a *app) sessionExpiresAt(loggedInAt int64) time.Time {
+	return time.Unix(loggedInAt, 0).Add(a.sessionLifetime)
+}
+
 func (a *app) DeleteSession(ctx context.Context, sessionID string) {
 	err := a.queries.DeleteUserSession(ctx, sessionID)
 	if err != nil {
@@ -0,0 +1,143 @@
+package wishlister_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+)
+
+// magicLinkSender keeps the magic link tokens instead of sending them.
+type magicLinkSender struct {
+	email.NoMailer
+
+	tokens chan string
+}
+
+func (s magicLinkSender) SendMagicLink(
+	_ context.Context,
+	_ string,
+	_ language.Tag,
+	token string,
+) error {
+	s.tokens <- token
+	return nil
+}
+
+func TestMagicLinkBinding(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		sender := magicLinkSender{tokens: make(chan string, 1)}
+		app, err := wishlister.NewWithConfig(wishlister.Config{
+			DB:          db,
+			Engine:      engine,
+			EmailSender: sender,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		browser := wishlister.WithLoginBinding(context.Background(), "browser")
+		sendMagicLink := func() string {
+			err := app.SendMagicLink(browser, "alice@example.com", language.English)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return <-sender.tokens
+		}
+
+		// The link is used in the browser it was asked from.
+		_, err = app.GetSessionByMagicLink(browser, sendMagicLink())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The link is used in another browser, or without a binding.
+		token := sendMagicLink()
+		other := wishlister.WithLoginBinding(context.Background(), "other")
+		for _, ctx := range []context.Context{other, context.Background()} {
+			_, err = app.GetSessionByMagicLink(ctx, token)
+			if !errors.Is(err, wishlister.ErrLoginBindingMismatch) {
+				t.Fatalf("got %v, expected ErrLoginBindingMismatch", err)
+			}
+		}
+
+		// The link is still valid, and can be used once the login is confirmed.
+		session, err := app.GetSessionByMagicLink(wishlister.WithLoginConfirmed(other), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if session.SessionID == "" {
+			t.Errorf("got session %+v", session)
+		}
+
+		_, err = app.GetSessionByMagicLink(wishlister.WithLoginConfirmed(other), token)
+		if !errors.Is(err, wishlister.ErrSessionNotFound) {
+			t.Errorf("got %v, expected ErrSessionNotFound", err)
+		}
+
+		_, err = app.GetSessionByMagicLink(browser, "unknown")
+		if !errors.Is(err, wishlister.ErrSessionNotFound) {
+			t.Errorf("got %v, expected ErrSessionNotFound", err)
+		}
+	})
+}
+
+// TestSessionLifetimeStartsAtLogin checks that a magic link used late does not give a
+// shorter session.
+func TestSessionLifetimeStartsAtLogin(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		sender := magicLinkSender{tokens: make(chan string, 1)}
+		app, err := wishlister.NewWithConfig(wishlister.Config{
+			DB:              db,
+			Engine:          engine,
+			EmailSender:     sender,
+			MagicLinkTTL:    3 * time.Second,
+			SessionLifetime: time.Hour,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = app.SendMagicLink(ctx, "alice@example.com", language.English)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The link is used near the end of its TTL.
+		token := <-sender.tokens
+		time.Sleep(2 * time.Second)
+		loggedInAt := time.Now().Truncate(time.Second)
+
+		session, err := app.GetSessionByMagicLink(ctx, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if session.ExpiresAt.Before(loggedInAt.Add(time.Hour)) {
+			t.Errorf(
+				"got a session expiring at %s, expected %s",
+				session.ExpiresAt,
+				loggedInAt.Add(time.Hour),
+			)
+		}
+
+		got, err := app.GetSession(ctx, session.SessionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.ExpiresAt.Equal(session.ExpiresAt) {
+			t.Errorf("got a session expiring at %s, expected %s", got.ExpiresAt, session.ExpiresAt)
+		}
+	})
+}
@@ -0,0 +1,668 @@
+package wishlister
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	nanoid "github.com/matoous/go-nanoid/v2"
+
+	"github.com/erdnaxeli/wishlister/pkg/repository"
+)
+
+// maxWebhookDeliveries is the maximum number of deliveries returned by
+// GetWebhookDeliveries.
+const maxWebhookDeliveries = 50
+
+const (
+	// webhookBatchSize is the maximum number of events fetched at once by the worker.
+	webhookBatchSize = 20
+	// webhookClaimDuration is how long an event is reserved for the worker sending it. It
+	// must be longer than a delivery, so another worker does not send it twice.
+	webhookClaimDuration = time.Minute
+	// webhookMaxDrain is the maximum size of a response read to reuse its connection.
+	// The response is not used, so a larger one is discarded with its connection.
+	webhookMaxDrain = 64 << 10
+)
+
+// errWebhookStatus is the error when a webhook answers with a status code other than
+// 2xx.
+var errWebhookStatus = errors.New("unexpected status code")
+
+// webhookPayload is the JSON body sent to webhooks.
+type webhookPayload struct {
+	ID    string             `json:"id"`
+	Event WebhookEvent       `json:"event"`
+	Time  time.Time          `json:"time"`
+	List  webhookPayloadList `json:"list"`
+	// Element is the element added, updated or removed.
+	Element *webhookPayloadElement `json:"element,omitempty"`
+	// Previous is the element before its update.
+	Previous *webhookPayloadElement `json:"previous,omitempty"`
+}
+
+type webhookPayloadList struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type webhookPayloadElement struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+func (a *app) AddWebhook(
+	ctx context.Context,
+	listID string,
+	adminID string,
+	webhookURL string,
+) (Webhook, error) {
+	_, err := a.checkListEditAccess(ctx, listID, adminID)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	err = checkWebhookURL(webhookURL)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return insertWebhook(ctx, a.queries, listID, webhookURL)
+}
+
+// checkWebhookURL returns an error ErrWebhookInvalidURL if the URL is not a valid HTTP
+// URL.
+func checkWebhookURL(webhookURL string) error {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") ||
+		parsedURL.Host == "" {
+		return ErrWebhookInvalidURL
+	}
+
+	return nil
+}
+
+func insertWebhook(
+	ctx context.Context,
+	queries repository.Store,
+	listID string,
+	webhookURL string,
+) (Webhook, error) {
+	webhookID, _ := nanoid.New()
+	secret, _ := nanoid.New(32)
+	now := time.Now()
+
+	err := queries.InsertWebhook(ctx, repository.InsertWebhookParams{
+		ID:         webhookID,
+		WishlistID: listID,
+		Url:        webhookURL,
+		Secret:     secret,
+		CreatedAt:  now.Unix(),
+	})
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return Webhook{
+		ID:        webhookID,
+		URL:       webhookURL,
+		Secret:    secret,
+		CreatedAt: time.Unix(now.Unix(), 0),
+	}, nil
+}
+
+func (a *app) GetWebhooks(ctx context.Context, listID string, adminID string) ([]Webhook, error) {
+	_, err := a.checkListEditAccess(ctx, listID, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooksData, err := a.queries.GetWishListWebhooks(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]Webhook, 0, len(webhooksData))
+	for _, webhookData := range webhooksData {
+		webhooks = append(webhooks, Webhook{
+			ID:        webhookData.ID,
+			URL:       webhookData.Url,
+			Secret:    webhookData.Secret,
+			CreatedAt: time.Unix(webhookData.CreatedAt, 0),
+		})
+	}
+
+	return webhooks, nil
+}
+
+func (a *app) GetWebhookDeliveries(
+	ctx context.Context,
+	listID string,
+	adminID string,
+) ([]WebhookDelivery, error) {
+	_, err := a.checkListEditAccess(ctx, listID, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveriesData, err := a.queries.GetWishListWebhookDeliveries(
+		ctx,
+		repository.GetWishListWebhookDeliveriesParams{
+			WishlistID: listID,
+			Limit:      maxWebhookDeliveries,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]WebhookDelivery, 0, len(deliveriesData))
+	for _, deliveryData := range deliveriesData {
+		deliveries = append(deliveries, WebhookDelivery{
+			ID:         deliveryData.ID,
+			WebhookID:  deliveryData.WebhookID,
+			URL:        deliveryData.Url,
+			Event:      WebhookEvent(deliveryData.Event),
+			Attempt:    int(deliveryData.Attempt),
+			StatusCode: int(deliveryData.StatusCode.Int64),
+			Error:      deliveryData.Error.String,
+			Time:       time.Unix(deliveryData.CreatedAt, 0),
+		})
+	}
+
+	return deliveries, nil
+}
+
+func (a *app) DeleteWebhook(
+	ctx context.Context,
+	listID string,
+	adminID string,
+	webhookID string,
+) (err error) {
+	_, err = a.checkListEditAccess(ctx, listID, adminID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	qtx := a.queries.WithTx(tx)
+	err = qtx.DeleteWebhookOutboxEvents(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteWebhookDeliveries(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+
+	count, err := qtx.DeleteWebhook(ctx, repository.DeleteWebhookParams{
+		ID:         webhookID,
+		WishlistID: listID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		err = ErrWebhookNotFound
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// queueListCreatedWebhooks queues the list created event for the given webhooks of the
+// new list.
+func queueListCreatedWebhooks(
+	ctx context.Context,
+	queries repository.Store,
+	list WishList,
+	webhooks []Webhook,
+) error {
+	webhookIDs := make([]string, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookIDs = append(webhookIDs, webhook.ID)
+	}
+
+	return queueWebhookEvents(ctx, queries, webhookIDs, []webhookPayload{
+		newWebhookPayload(WebhookListCreated, list),
+	})
+}
+
+// queueElementsWebhooks queues the events corresponding to the changes between the
+// previous and the new elements of a wishlist, for all its webhooks.
+func queueElementsWebhooks(
+	ctx context.Context,
+	queries repository.Store,
+	list WishList,
+	previousElements []WishListElement,
+	newElements []WishListElement,
+) error {
+	webhooksData, err := queries.GetWishListWebhooks(ctx, list.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(webhooksData) == 0 {
+		return nil
+	}
+
+	webhookIDs := make([]string, 0, len(webhooksData))
+	for _, webhookData := range webhooksData {
+		webhookIDs = append(webhookIDs, webhookData.ID)
+	}
+
+	var payloads []webhookPayload
+	added, updated, removed := diffElementsByName(previousElements, newElements)
+
+	for _, element := range added {
+		payload := newWebhookPayload(WebhookElementAdded, list)
+		payload.Element = newWebhookPayloadElement(element)
+		payloads = append(payloads, payload)
+	}
+
+	for _, elements := range updated {
+		payload := newWebhookPayload(WebhookElementUpdated, list)
+		payload.Previous = newWebhookPayloadElement(elements[0])
+		payload.Element = newWebhookPayloadElement(elements[1])
+		payloads = append(payloads, payload)
+	}
+
+	for _, element := range removed {
+		payload := newWebhookPayload(WebhookElementRemoved, list)
+		payload.Element = newWebhookPayloadElement(element)
+		payloads = append(payloads, payload)
+	}
+
+	return queueWebhookEvents(ctx, queries, webhookIDs, payloads)
+}
+
+// queueWebhookEvents adds each payload for each webhook to the outbox, to be sent by
+// the webhooks worker.
+//
+// Like queueEmail, the queries should be run in the transaction of the change the
+// events are about.
+func queueWebhookEvents(
+	ctx context.Context,
+	queries repository.Store,
+	webhookIDs []string,
+	payloads []webhookPayload,
+) error {
+	now := time.Now().Unix()
+
+	for _, payload := range payloads {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		for _, webhookID := range webhookIDs {
+			eventID, _ := nanoid.New()
+			err = queries.InsertWebhookOutboxEvent(ctx, repository.InsertWebhookOutboxEventParams{
+				ID:            eventID,
+				WebhookID:     webhookID,
+				Event:         string(payload.Event),
+				Payload:       string(body),
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// wakeUpWebhooks makes the webhooks worker look for due events now, instead of waiting
+// for its next poll.
+func (a *app) wakeUpWebhooks() {
+	select {
+	case a.webhooksWakeUp <- struct{}{}:
+	default:
+		// The worker is already going to wake up.
+	}
+}
+
+// runWebhooks sends the events of the webhooks outbox until the context is canceled.
+//
+// A failed event is retried with an exponential backoff, up to webhookAttempts times,
+// then dropped. Each attempt is logged in the deliveries of the webhook.
+//
+// The events are sent in the order they were queued, but a retried event may be
+// received after the next ones: receivers should rely on the time of the payloads.
+func (a *app) runWebhooks(ctx context.Context) {
+	ticker := time.NewTicker(a.webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		a.sendDueWebhookEvents(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-a.webhooksWakeUp:
+		}
+	}
+}
+
+// sendDueWebhookEvents sends the events of the webhooks outbox that are due, batch by
+// batch.
+func (a *app) sendDueWebhookEvents(ctx context.Context) {
+	for {
+		events, err := a.queries.GetDueWebhookOutboxEvents(
+			ctx,
+			repository.GetDueWebhookOutboxEventsParams{
+				NextAttemptAt: time.Now().Unix(),
+				Limit:         webhookBatchSize,
+			},
+		)
+		if err != nil {
+			log.Printf("Error while getting webhook events: %s", err)
+			return
+		}
+
+		for _, event := range events {
+			a.sendWebhookEvent(ctx, event)
+		}
+
+		if len(events) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+func (a *app) sendWebhookEvent(
+	ctx context.Context,
+	event repository.GetDueWebhookOutboxEventsRow,
+) {
+	count, err := a.queries.ClaimWebhookOutboxEvent(
+		ctx,
+		repository.ClaimWebhookOutboxEventParams{
+			ClaimedUntil:  time.Now().Add(webhookClaimDuration).Unix(),
+			ID:            event.ID,
+			NextAttemptAt: event.NextAttemptAt,
+		},
+	)
+	if err != nil {
+		log.Printf("Error while claiming webhook event %s: %s", event.ID, err)
+		return
+	}
+
+	if count == 0 {
+		// Another worker is sending it.
+		return
+	}
+
+	attempts := event.Attempts + 1
+	statusCode, sendErr := a.postWebhook(ctx, event)
+	a.logWebhookDelivery(ctx, event, attempts, statusCode, sendErr)
+
+	if sendErr == nil || attempts >= int64(a.webhookAttempts) {
+		if sendErr != nil {
+			log.Printf("Giving up sending webhook event %s: %s", event.ID, sendErr)
+		}
+
+		err = a.queries.DeleteWebhookOutboxEvent(ctx, event.ID)
+		if err != nil {
+			log.Printf("Error while deleting webhook event %s: %s", event.ID, err)
+		}
+
+		return
+	}
+
+	log.Printf("Error while sending webhook event %s, will retry: %s", event.ID, sendErr)
+	delay := a.webhookRetryDelay << (attempts - 1)
+	err = a.queries.UpdateWebhookOutboxEventAttempt(
+		ctx,
+		repository.UpdateWebhookOutboxEventAttemptParams{
+			ID:            event.ID,
+			Attempts:      attempts,
+			NextAttemptAt: time.Now().Add(delay).Unix(),
+		},
+	)
+	if err != nil {
+		log.Printf("Error while updating webhook event %s: %s", event.ID, err)
+	}
+}
+
+func (a *app) postWebhook(
+	ctx context.Context,
+	event repository.GetDueWebhookOutboxEventsRow,
+) (int, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		event.Url,
+		strings.NewReader(event.Payload),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "wishlister-webhook")
+	req.Header.Set("X-Wishlister-Event", event.Event)
+	req.Header.Set("X-Wishlister-Delivery", event.ID)
+	req.Header.Set(
+		"X-Wishlister-Signature",
+		SignWebhookPayload(event.Secret, []byte(event.Payload)),
+	)
+
+	resp, err := a.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// The body is read so the connection can be reused for the next deliveries.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxDrain))
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%w: %d", errWebhookStatus, resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (a *app) logWebhookDelivery(
+	ctx context.Context,
+	event repository.GetDueWebhookOutboxEventsRow,
+	attempt int64,
+	statusCode int,
+	deliveryErr error,
+) {
+	deliveryID, _ := nanoid.New()
+	params := repository.InsertWebhookDeliveryParams{
+		ID:        deliveryID,
+		WebhookID: event.WebhookID,
+		Event:     event.Event,
+		Payload:   event.Payload,
+		Attempt:   attempt,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	if statusCode != 0 {
+		params.StatusCode.Int64 = int64(statusCode)
+		params.StatusCode.Valid = true
+	}
+
+	if deliveryErr != nil {
+		params.Error = NewNullString(webhookDeliveryError(deliveryErr))
+	}
+
+	err := a.queries.InsertWebhookDelivery(ctx, params)
+	if err != nil {
+		// The webhook may have been deleted in the meantime.
+		log.Printf("Error while logging delivery of webhook %s: %s", event.WebhookID, err)
+	}
+}
+
+// webhookDeliveryError returns the reason of a failed delivery shown to the owner of
+// the webhook.
+//
+// The raw errors are only logged, as they would tell the owner about the network of the
+// server, like the addresses resolved or the ports open.
+func webhookDeliveryError(err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, errWebhookStatus):
+		return "unexpected status code"
+	case errors.Is(err, ErrWebhookForbiddenAddress):
+		return "forbidden address"
+	case errors.As(err, &dnsErr):
+		return "host not found"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	default:
+		return "connection failed"
+	}
+}
+
+// newWebhookClient returns the HTTP client used to send the webhooks.
+//
+// Unless allowPrivateNetworks is true, it refuses to connect to the addresses which are
+// not public, so the webhooks cannot be used to reach the services of the network of
+// the server. The addresses are checked once resolved, so a host name resolving to a
+// private address is refused too.
+func newWebhookClient(allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = checkWebhookAddress
+	}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			// No proxy: the check would apply to the address of the proxy instead of the
+			// one of the webhook.
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+		// The receivers must answer directly, a redirection is a failure.
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkWebhookAddress returns an error ErrWebhookForbiddenAddress if the address about
+// to be dialed is not a public one.
+func checkWebhookAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	if !isPublicAddress(ip) {
+		return fmt.Errorf("%w: %s", ErrWebhookForbiddenAddress, ip)
+	}
+
+	return nil
+}
+
+// nonPublicPrefixes are the reserved ranges not covered by the methods of netip.Addr.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	// Shared address space, used by carrier-grade NATs.
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	// Benchmarking.
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	// NAT64, which could translate to any IPv4 address.
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+func isPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SignWebhookPayload returns the signature of a webhook payload, as sent in the
+// X-Wishlister-Signature header.
+//
+// The signature is the HMAC-SHA256 of the body using the webhook secret as key,
+// encoded in hexadecimal and prefixed by "sha256=". Receivers should compute it and
+// compare it with the header to authenticate the payload.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookPayload(event WebhookEvent, list WishList) webhookPayload {
+	payloadID, _ := nanoid.New()
+
+	return webhookPayload{
+		ID:    payloadID,
+		Event: event,
+		Time:  time.Now().UTC(),
+		List: webhookPayloadList{
+			ID:   list.ID,
+			Name: list.Name,
+		},
+	}
+}
+
+func newWebhookPayloadElement(element WishListElement) *webhookPayloadElement {
+	return &webhookPayloadElement{
+		Name:        element.Name,
+		Description: element.Description,
+		URL:         element.URL,
+	}
+}
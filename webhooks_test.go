@@ -0,0 +1,279 @@
+package wishlister_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/erdnaxeli/wishlister"
+	"github.com/erdnaxeli/wishlister/pkg/dbtest"
+	"github.com/erdnaxeli/wishlister/pkg/email"
+)
+
+// receivedWebhook is a request received by a webhookReceiver.
+type receivedWebhook struct {
+	Header http.Header
+	Body   []byte
+	Event  struct {
+		Event   wishlister.WebhookEvent `json:"event"`
+		List    struct{ ID string }     `json:"list"`
+		Element struct{ Name string }   `json:"element"`
+	}
+}
+
+// newWebhookReceiver starts a server receiving webhooks. It answers with the given
+// status codes, then with 204.
+func newWebhookReceiver(t *testing.T, statusCodes ...int) (string, <-chan receivedWebhook) {
+	t.Helper()
+
+	received := make(chan receivedWebhook, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhook := receivedWebhook{Header: r.Header}
+		webhook.Body, _ = io.ReadAll(r.Body)
+		err := json.Unmarshal(webhook.Body, &webhook.Event)
+		if err != nil {
+			t.Errorf("invalid payload %s: %s", webhook.Body, err)
+		}
+
+		received <- webhook
+
+		if len(statusCodes) > 0 {
+			w.WriteHeader(statusCodes[0])
+			statusCodes = statusCodes[1:]
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, received
+}
+
+func receiveWebhook(t *testing.T, received <-chan receivedWebhook) receivedWebhook {
+	t.Helper()
+
+	select {
+	case webhook := <-received:
+		return webhook
+	case <-time.After(5 * time.Second):
+		t.Fatal("no webhook received")
+		return receivedWebhook{}
+	}
+}
+
+func newWebhooksApp(
+	t *testing.T,
+	db *sql.DB,
+	engine wishlister.Engine,
+	allowPrivateNetworks bool,
+) wishlister.App {
+	t.Helper()
+
+	app, err := wishlister.NewWithConfig(wishlister.Config{
+		DB:          db,
+		Engine:      engine,
+		EmailSender: email.NoMailer{},
+
+		WebhookAllowPrivateNetworks: allowPrivateNetworks,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wishlister.StartWebhooks(t.Context(), app, 10*time.Millisecond, 0)
+
+	return app
+}
+
+func TestWebhooks(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		app := newWebhooksApp(t, db, engine, true)
+		receiverURL, received := newWebhookReceiver(t)
+
+		listID, adminID, err := app.CreateWishList(ctx, wishlister.CreateWishlistParams{
+			Name:        "Birthday",
+			Username:    "Alice",
+			UserEmail:   "alice@example.com",
+			WebhookURLs: []string{receiverURL},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		webhooks, err := app.GetWebhooks(ctx, listID, adminID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(webhooks) != 1 {
+			t.Fatalf("got %d webhooks, expected 1", len(webhooks))
+		}
+
+		webhook := receiveWebhook(t, received)
+		if webhook.Event.Event != wishlister.WebhookListCreated ||
+			webhook.Event.List.ID != listID {
+			t.Errorf("got event %+v, expected the creation of the list", webhook.Event)
+		}
+
+		signature := wishlister.SignWebhookPayload(webhooks[0].Secret, webhook.Body)
+		if webhook.Header.Get("X-Wishlister-Signature") != signature {
+			t.Errorf("got an invalid signature %s", webhook.Header.Get("X-Wishlister-Signature"))
+		}
+
+		// The creation of another list of the same user is not sent to the webhooks of
+		// the first one.
+		_, _, err = app.CreateWishList(ctx, wishlister.CreateWishlistParams{
+			Name:      "Christmas",
+			Username:  "Alice",
+			UserEmail: "alice@example.com",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = app.UpdateListElements(ctx, listID, adminID, []wishlister.WishListElement{
+			{Name: "Book"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		webhook = receiveWebhook(t, received)
+		if webhook.Event.Event != wishlister.WebhookElementAdded ||
+			webhook.Event.List.ID != listID ||
+			webhook.Event.Element.Name != "Book" {
+			t.Errorf("got event %+v, expected the addition of the element", webhook.Event)
+		}
+	})
+}
+
+func TestWebhooksRetry(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		app := newWebhooksApp(t, db, engine, true)
+		receiverURL, received := newWebhookReceiver(t, http.StatusInternalServerError)
+
+		listID, adminID, err := app.CreateWishList(ctx, wishlister.CreateWishlistParams{
+			Name:        "Birthday",
+			Username:    "Alice",
+			WebhookURLs: []string{receiverURL},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first := receiveWebhook(t, received)
+		second := receiveWebhook(t, received)
+		if first.Header.Get("X-Wishlister-Delivery") != second.Header.Get("X-Wishlister-Delivery") {
+			t.Errorf("the retry has another delivery ID")
+		}
+
+		deliveries := waitWebhookDeliveries(t, app, listID, adminID, 2)
+		// The most recent first.
+		if deliveries[1].Attempt != 1 ||
+			deliveries[1].StatusCode != http.StatusInternalServerError ||
+			deliveries[1].Error != "unexpected status code" {
+			t.Errorf("got first delivery %+v", deliveries[1])
+		}
+
+		if deliveries[0].Attempt != 2 ||
+			deliveries[0].StatusCode != http.StatusNoContent ||
+			deliveries[0].Error != "" {
+			t.Errorf("got second delivery %+v", deliveries[0])
+		}
+	})
+}
+
+func TestWebhooksForbiddenAddress(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *sql.DB, engine wishlister.Engine) {
+		ctx := context.Background()
+		app := newWebhooksApp(t, db, engine, false)
+		receiverURL, received := newWebhookReceiver(t)
+
+		listID, adminID, err := app.CreateWishList(ctx, wishlister.CreateWishlistParams{
+			Name:        "Birthday",
+			Username:    "Alice",
+			WebhookURLs: []string{receiverURL},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// All the attempts fail.
+		deliveries := waitWebhookDeliveries(t, app, listID, adminID, 4)
+		for _, delivery := range deliveries {
+			if delivery.Error != "forbidden address" {
+				t.Errorf("got delivery %+v, expected a forbidden address", delivery)
+			}
+		}
+
+		select {
+		case webhook := <-received:
+			t.Errorf("the loopback address was called: %s", webhook.Body)
+		default:
+		}
+	})
+}
+
+func waitWebhookDeliveries(
+	t *testing.T,
+	app wishlister.App,
+	listID string,
+	adminID string,
+	count int,
+) []wishlister.WebhookDelivery {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		deliveries, err := app.GetWebhookDeliveries(context.Background(), listID, adminID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(deliveries) >= count {
+			return deliveries
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d deliveries, expected %d", len(deliveries), count)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	for address, expected := range map[string]bool{
+		"93.184.215.14":          true,
+		"2606:4700:4700::1111":   true,
+		"::ffff:93.184.215.14":   true,
+		"127.0.0.1":              false,
+		"::1":                    false,
+		"0.0.0.0":                false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"100.64.0.1":             false,
+		"169.254.169.254":        false,
+		"224.0.0.1":              false,
+		"fd00::1":                false,
+		"fe80::1":                false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:169.254.169.254": false,
+		"64:ff9b::a00:1":         false,
+	} {
+		ip := netip.MustParseAddr(address)
+		if wishlister.IsPublicAddress(ip) != expected {
+			t.Errorf("%s: got public %t, expected %t", address, !expected, expected)
+		}
+	}
+}